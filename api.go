@@ -3,6 +3,7 @@ package ecs
 import (
 	"context"
 	"io"
+	"sync"
 	"time"
 )
 
@@ -12,26 +13,62 @@ type Scheduler interface {
 	Run(ctx context.Context, steps int, dt time.Duration) error
 	RunWithTrace(ctx context.Context, w io.Writer, fn func() error) error
 	RegisterWorkGroup(cfg WorkGroupConfig) (WorkGroupHandle, error)
+	// ResetCircuit re-enables a system whose retry circuit opened. It takes
+	// effect at the start of the next tick.
+	ResetCircuit(group WorkGroupID, system string) error
+	// UnregisterWorkGroup, Pause* and Resume* apply immediately between ticks.
+	// During a tick, including from systems and deferred commands, they take
+	// effect at the next tick boundary. An unregistered SpanTicks group still in
+	// flight shuts down once its job finishes.
+	UnregisterWorkGroup(id WorkGroupID) error
+	PauseWorkGroup(id WorkGroupID) error
+	ResumeWorkGroup(id WorkGroupID) error
+	PauseSystem(group WorkGroupID, system string) error
+	ResumeSystem(group WorkGroupID, system string) error
+	// Shutdown drains in-flight work, closes worker goroutines and runs
+	// SystemShutdowner callbacks. Later ticks return ErrSchedulerClosed.
+	Shutdown(ctx context.Context) error
 	Builder() SchedulerBuilder
 }
 
-// SchedulerBuilder configures scheduler options prior to construction.
+// SchedulerBuilder configures scheduler options prior to construction. An
+// option that cannot be applied, such as a sync order the group constraints
+// reject, is logged and returned by the next Build.
 type SchedulerBuilder interface {
 	WithSyncOrder(order []WorkGroupID) SchedulerBuilder
 	WithAsyncWorkers(count int) SchedulerBuilder
 	WithErrorPolicy(id WorkGroupID, policy ErrorPolicy) SchedulerBuilder
+	WithRetryPolicy(id WorkGroupID, policy RetryPolicy) SchedulerBuilder
 	WithInstrumentation(cfg InstrumentationConfig) SchedulerBuilder
+	WithParallelGroups(enabled bool) SchedulerBuilder
+	WithShutdownPolicy(policy ShutdownPolicy) SchedulerBuilder
 	Build(world *World) (Scheduler, error)
 }
 
 // WorkGroupConfig declares a set of systems and execution preferences.
 type WorkGroupConfig struct {
-	ID          WorkGroupID
-	Mode        WorkGroupMode
+	ID   WorkGroupID
+	Mode WorkGroupMode
+	// SpanTicks lets an async group keep running across tick boundaries. It is
+	// not dispatched again while in flight, and its commands apply at the first
+	// tick boundary after it completes. Its systems see a copy of the world
+	// taken at dispatch that holds only the components they declare as Reads.
+	// The job runs on its own goroutine, not an async worker.
+	SpanTicks   bool
 	Systems     []System
 	Interval    TickInterval
 	ErrorPolicy ErrorPolicy
-	Priority    int
+	// Retry overrides how failing systems in the group are retried. Systems
+	// may override it again through SystemDescriptor.Retry.
+	Retry    *RetryPolicy
+	Priority int
+	// Labels, Before and After order groups relative to each other. Before and
+	// After reference group IDs or labels; unknown references are ignored.
+	// Async groups run beside the sync groups, so constraints that resolve to
+	// one fail registration with ErrAsyncOrdering.
+	Labels []string
+	Before []string
+	After  []string
 }
 
 // WorkGroupMode selects synchronous or asynchronous execution.
@@ -65,12 +102,39 @@ const (
 	ErrorPolicyRetry
 )
 
+// RetryPolicy controls how a failing system is retried. ErrorPolicyRetry
+// without an explicit policy behaves like RetryPolicy{MaxAttempts: 2}.
+type RetryPolicy struct {
+	// MaxAttempts bounds consecutive attempts, including the first. Immediate
+	// retries report only the final failure to the work group's ErrorPolicy.
+	// Values below 1 mean 1.
+	MaxAttempts int
+	// BackoffTicks delays each retry by this many ticks instead of rerunning
+	// immediately. Every failed attempt is reported to the work group's
+	// ErrorPolicy, and the system is skipped while it waits.
+	BackoffTicks uint64
+	// RetryIf limits retries to matching errors; nil retries every error.
+	RetryIf func(error) bool
+	// CircuitBreakAfter disables the system after it fails this many ticks in
+	// a row, until Scheduler.ResetCircuit is called. Zero disables the breaker.
+	CircuitBreakAfter int
+}
+
 // InstrumentationConfig configures logging, tracing, and metrics sinks.
 type InstrumentationConfig struct {
+	// EnableTrace opens runtime/trace tasks per tick and regions per work
+	// group and system, unless Tracer is supplied.
 	EnableTrace   bool
 	EnableMetrics bool
-	Observer      SchedulerObserver
-	Observation   ObservationSettings
+	// Tracer replaces the built-in runtime/trace tracer. It receives a span per
+	// tick, work group, system and async wait, and is exposed to systems
+	// through ExecutionContext.Tracer.
+	Tracer      Tracer
+	Observer    SchedulerObserver
+	Observation ObservationSettings
+	// CommandObserver sees every command deferred by a system as it applies,
+	// with its provenance.
+	CommandObserver CommandObserver
 }
 
 // ObservationSettings toggles built-in observer integrations.
@@ -99,6 +163,21 @@ type SchedulerObserver interface {
 	WorkGroupCompleted(summary WorkGroupSummary)
 }
 
+// CircuitObserver is an optional SchedulerObserver extension notified when a
+// retry circuit opens and disables a system.
+type CircuitObserver interface {
+	SystemCircuitOpened(event CircuitEvent)
+}
+
+// CircuitEvent describes a system disabled by its retry circuit breaker.
+type CircuitEvent struct {
+	WorkGroupID WorkGroupID
+	System      string
+	Tick        uint64
+	Failures    int
+	Error       error
+}
+
 // PrometheusCollector handles work-group summaries for Prometheus-style metrics.
 type PrometheusCollector interface {
 	ObserveWorkGroup(summary WorkGroupSummary)
@@ -121,10 +200,13 @@ type SigNozOptions struct {
 
 // WorkGroupSummary captures execution metadata for a work group.
 type WorkGroupSummary struct {
-	WorkGroupID     WorkGroupID
-	Mode            WorkGroupMode
-	Async           bool
-	Tick            uint64
+	WorkGroupID WorkGroupID
+	Mode        WorkGroupMode
+	Async       bool
+	Tick        uint64 // tick the group was dispatched on
+	// Staleness counts the ticks between dispatch and the boundary at which
+	// the group's commands applied; non-zero only for SpanTicks groups.
+	Staleness       uint64
 	Duration        time.Duration
 	SystemsTotal    int
 	SystemsExecuted int
@@ -134,6 +216,38 @@ type WorkGroupSummary struct {
 	ComponentWrites []ComponentType
 	ResourceReads   []string
 	ResourceWrites  []string
+	// Systems breaks the run down per system, in execution order.
+	Systems []SystemSummary
+}
+
+// SystemStatus reports how a system fared during a work group run.
+type SystemStatus uint8
+
+const (
+	SystemStatusExecuted SystemStatus = iota
+	SystemStatusSkipped
+	SystemStatusFailed
+)
+
+func (s SystemStatus) String() string {
+	switch s {
+	case SystemStatusSkipped:
+		return "skipped"
+	case SystemStatusFailed:
+		return "failed"
+	default:
+		return "executed"
+	}
+}
+
+// SystemSummary captures execution metadata for one system within a work group.
+type SystemSummary struct {
+	Name     string
+	Status   SystemStatus
+	Duration time.Duration // wall time across every attempt this tick
+	Retries  int           // attempts beyond the first in the current failure streak
+	Commands int           // commands the system enqueued and kept
+	Error    error
 }
 
 // System represents executable logic within a work group.
@@ -148,9 +262,16 @@ type SystemDescriptor struct {
 	Reads        []ComponentType
 	Writes       []ComponentType
 	Resources    []ResourceAccess
+	Events       []EventAccess
+	Retry        *RetryPolicy
 	Tags         []string
 	RunEvery     TickInterval
 	AsyncAllowed bool
+	// Labels, Before and After order systems within their work group. Before
+	// and After reference system names or labels; unknown references are ignored.
+	Labels []string
+	Before []string
+	After  []string
 }
 
 // SystemResult indicates how a system behaved during execution.
@@ -164,15 +285,32 @@ type ExecutionContext interface {
 	World() *World
 	TimeDelta() time.Duration
 	TickIndex() uint64
+	// LastRunTick reports the tick at which the running system last completed,
+	// or false on its first run.
+	LastRunTick() (uint64, bool)
 	Logger() Logger
+	// Tracer starts sub-spans; pass the context given to System.Run so they
+	// nest under the system's span.
+	Tracer() Tracer
 	Defer(cmd Command)
 }
 
 // World encapsulates entity/component storage and resources.
 type World struct {
-	registry  *EntityRegistry
-	storage   StorageProvider
-	resources ResourceContainer
+	registry     *EntityRegistry
+	storage      StorageProvider
+	resources    ResourceContainer
+	types        componentTypeRegistry
+	registered   registeredComponents // types registered through the world
+	codecs       snapshotCodecs
+	hooks        componentHookRegistry
+	changes      changeTracker
+	events       eventBus
+	hierarchy    entityHierarchy
+	placeholders map[EntityID]EntityID // spawned entities by placeholder, reset per ApplyCommands
+	applyMode    ApplyMode
+	journal      *applyJournal // non-nil during a transactional ApplyCommands
+	applyMu      sync.RWMutex  // write-held while commands apply; see World.Read
 }
 
 // StorageProvider manages component storage backends.
@@ -182,6 +320,27 @@ type StorageProvider interface {
 	Apply(*World, []Command) error
 }
 
+// ComponentLister is implemented by storage providers that can enumerate their
+// registered component types. For providers without it the world only knows
+// the types registered through World.RegisterComponent.
+type ComponentLister interface {
+	Components() []ComponentType
+}
+
+// EntityRemover is implemented by storage providers that can drop every
+// component of an entity in one step, such as table-based layouts. Providers
+// without it are swept store by store when an entity is destroyed.
+type EntityRemover interface {
+	RemoveEntity(EntityID)
+}
+
+// EntityReleaser is an opt-in hook for component stores that hold per-entity
+// resources beyond the component value. It runs after the entity's component
+// has been removed during destruction.
+type EntityReleaser interface {
+	ReleaseEntity(EntityID)
+}
+
 // StorageStrategy describes how a component type is stored internally.
 type StorageStrategy interface {
 	Name() string
@@ -197,6 +356,14 @@ type ResourceAccess struct {
 	Mode AccessMode
 }
 
+// EventAccess declares that a system emits (write) or consumes (read) events
+// on a named channel. Unlike resources, readers may live in other groups than
+// the writer.
+type EventAccess struct {
+	Name string
+	Mode AccessMode
+}
+
 // AccessMode indicates read or write intent when using a resource.
 type AccessMode uint8
 
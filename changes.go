@@ -0,0 +1,161 @@
+package ecs
+
+import (
+	"maps"
+	"sync"
+)
+
+// ComponentTicks records when a component value was added to an entity and
+// when it was last set. Each is the first scheduler tick whose systems could
+// observe the mutation.
+type ComponentTicks struct {
+	Added   uint64
+	Changed uint64
+}
+
+// ChangeTick returns the tick stamped on component mutations applied now: the
+// current tick while systems run, and the next tick once they have finished.
+func (w *World) ChangeTick() uint64 {
+	return w.changes.currentTick()
+}
+
+// ComponentTicks reports the change ticks recorded for the entity's component.
+// Only mutations applied through commands are tracked.
+func (w *World) ComponentTicks(t ComponentType, id EntityID) (ComponentTicks, bool) {
+	return w.changes.lookup(t, id)
+}
+
+// changeTracker stores change ticks per component type and entity index, so
+// detection works the same for every storage strategy.
+type changeTracker struct {
+	mu      sync.RWMutex
+	tick    uint64
+	entries map[ComponentType]map[uint32]trackedTicks
+}
+
+type trackedTicks struct {
+	id    EntityID
+	ticks ComponentTicks
+}
+
+func (c *changeTracker) currentTick() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tick
+}
+
+func (c *changeTracker) setTick(tick uint64) {
+	c.mu.Lock()
+	c.tick = tick
+	c.mu.Unlock()
+}
+
+// stamp records a set of the component. Replacing a value held by an older
+// generation counts as an addition.
+func (c *changeTracker) stamp(t ComponentType, id EntityID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[ComponentType]map[uint32]trackedTicks)
+	}
+	byIndex := c.entries[t]
+	if byIndex == nil {
+		byIndex = make(map[uint32]trackedTicks)
+		c.entries[t] = byIndex
+	}
+	entry, ok := byIndex[id.index]
+	if !ok || entry.id != id {
+		entry = trackedTicks{id: id, ticks: ComponentTicks{Added: c.tick}}
+	}
+	entry.ticks.Changed = c.tick
+	byIndex[id.index] = entry
+}
+
+// save captures the ticks stored for id's slot and returns a func restoring
+// them.
+func (c *changeTracker) save(t ComponentType, id EntityID) func() {
+	c.mu.RLock()
+	entry, ok := c.entries[t][id.index]
+	c.mu.RUnlock()
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !ok {
+			delete(c.entries[t], id.index)
+			return
+		}
+		if c.entries == nil {
+			c.entries = make(map[ComponentType]map[uint32]trackedTicks)
+		}
+		if c.entries[t] == nil {
+			c.entries[t] = make(map[uint32]trackedTicks)
+		}
+		c.entries[t][id.index] = entry
+	}
+}
+
+// clone copies the ticks stored for t.
+func (c *changeTracker) clone(t ComponentType) map[uint32]trackedTicks {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return maps.Clone(c.entries[t])
+}
+
+// saveType captures every tick stored for t and returns a func restoring them.
+func (c *changeTracker) saveType(t ComponentType) func() {
+	saved := c.clone(t)
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if saved == nil {
+			delete(c.entries, t)
+			return
+		}
+		if c.entries == nil {
+			c.entries = make(map[ComponentType]map[uint32]trackedTicks)
+		}
+		c.entries[t] = saved
+	}
+}
+
+func (c *changeTracker) forget(t ComponentType, id EntityID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[t][id.index]; ok && entry.id == id {
+		delete(c.entries[t], id.index)
+	}
+}
+
+func (c *changeTracker) clear(t ComponentType) {
+	c.mu.Lock()
+	delete(c.entries, t)
+	c.mu.Unlock()
+}
+
+func (c *changeTracker) lookup(t ComponentType, id EntityID) (ComponentTicks, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[t][id.index]
+	if !ok || entry.id != id {
+		return ComponentTicks{}, false
+	}
+	return entry.ticks, true
+}
+
+// all copies the ticks stored for every component type.
+func (c *changeTracker) all() map[ComponentType]map[uint32]trackedTicks {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[ComponentType]map[uint32]trackedTicks, len(c.entries))
+	for t, byIndex := range c.entries {
+		out[t] = maps.Clone(byIndex)
+	}
+	return out
+}
+
+// replace swaps every stored tick for entries.
+func (c *changeTracker) replace(entries map[ComponentType]map[uint32]trackedTicks) {
+	c.mu.Lock()
+	c.entries = entries
+	c.mu.Unlock()
+}
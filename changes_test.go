@@ -0,0 +1,144 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+func TestWorldRecordsComponentTicks(t *testing.T) {
+	world := ecs.NewWorld()
+	_ = world.RegisterComponent("Health", ecsstorage.NewDenseStrategy())
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	id := world.Registry().Create()
+	if err := world.ApplyCommands([]ecs.Command{ecs.NewAddComponentCommand(id, "Health", 10)}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if err := scheduler.Run(context.Background(), 3, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if err := world.ApplyCommands([]ecs.Command{ecs.NewAddComponentCommand(id, "Health", 5)}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	ticks, ok := world.ComponentTicks("Health", id)
+	if !ok || ticks.Added != 0 || ticks.Changed != world.ChangeTick() || ticks.Changed == 0 {
+		t.Fatalf("unexpected ticks %+v ok=%v (change tick %d)", ticks, ok, world.ChangeTick())
+	}
+
+	if err := world.ApplyCommands([]ecs.Command{ecs.NewDestroyEntityCommand(id)}); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+	recycled := world.Registry().Create()
+	if _, ok := world.ComponentTicks("Health", recycled); ok {
+		t.Fatalf("recycled entity must not inherit change ticks")
+	}
+}
+
+type changedHealthSystem struct {
+	query *ecs.Query
+	seen  [][]ecs.EntityID
+}
+
+func (s *changedHealthSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: "changed-health", Reads: []ecs.ComponentType{"Health"}}
+}
+
+func (s *changedHealthSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	var ids []ecs.EntityID
+	err := s.query.EachChanged(exec, func(row *ecs.QueryRow) bool {
+		ids = append(ids, row.Entity())
+		return true
+	})
+	s.seen = append(s.seen, ids)
+	return ecs.SystemResult{Err: err}
+}
+
+func TestQueryEachChangedSinceLastRun(t *testing.T) {
+	world := ecs.NewWorld()
+	_ = world.RegisterComponent("Health", ecsstorage.NewDenseStrategy())
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	a := world.Registry().Create()
+	b := world.Registry().Create()
+	err = world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(a, "Health", 1),
+		ecs.NewAddComponentCommand(b, "Health", 1),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	var damage ecs.EntityID
+	damager := &testSystem{
+		name: "damager",
+		desc: ecs.SystemDescriptor{Writes: []ecs.ComponentType{"Health"}},
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			if !damage.IsZero() {
+				ctx.Defer(ecs.NewAddComponentCommand(damage, "Health", 0))
+				damage = ecs.EntityID{}
+			}
+		},
+	}
+	observer := &changedHealthSystem{query: ecs.MustQuery(ecs.QueryConfig{
+		With:    []ecs.ComponentType{"Health"},
+		Changed: []ecs.ComponentType{"Health"},
+	})}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "damage", Systems: []ecs.System{damager}}); err != nil {
+		t.Fatalf("register damage: %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "observe", Systems: []ecs.System{observer}}); err != nil {
+		t.Fatalf("register observe: %v", err)
+	}
+
+	tick := func() {
+		t.Helper()
+		if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+			t.Fatalf("tick: %v", err)
+		}
+	}
+	tick() // first run sees everything
+	tick() // nothing changed since
+	if err := world.ApplyCommands([]ecs.Command{ecs.NewAddComponentCommand(a, "Health", 2)}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	tick() // external change to a
+	damage = b
+	tick() // damage to b is deferred until the end of this tick
+	tick() // so it becomes visible here
+
+	want := [][]ecs.EntityID{{a, b}, nil, {a}, nil, {b}}
+	if len(observer.seen) != len(want) {
+		t.Fatalf("unexpected runs: %v", observer.seen)
+	}
+	for i := range want {
+		if len(observer.seen[i]) != len(want[i]) {
+			t.Fatalf("run %d: got %v want %v", i, observer.seen[i], want[i])
+		}
+		for j := range want[i] {
+			if observer.seen[i][j] != want[i][j] {
+				t.Fatalf("run %d: got %v want %v", i, observer.seen[i], want[i])
+			}
+		}
+	}
+}
+
+func TestQueryChangeFiltersRequireWith(t *testing.T) {
+	_, err := ecs.NewQuery(ecs.QueryConfig{
+		With:  []ecs.ComponentType{"Health"},
+		Added: []ecs.ComponentType{"Position"},
+	})
+	if !errors.Is(err, ecs.ErrQueryConflictingFilter) {
+		t.Fatalf("expected ErrQueryConflictingFilter, got %v", err)
+	}
+}
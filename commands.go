@@ -7,7 +7,8 @@ func NewCreateEntityCommand(target *EntityID) Command {
 	return createEntityCommand{target: target}
 }
 
-// NewDestroyEntityCommand enqueues an entity deletion.
+// NewDestroyEntityCommand enqueues an entity deletion. Descendants in the
+// entity hierarchy are destroyed with it, children first.
 func NewDestroyEntityCommand(id EntityID) Command {
 	return destroyEntityCommand{entity: id}
 }
@@ -42,8 +43,10 @@ type removeComponentCommand struct {
 }
 
 func (c createEntityCommand) Apply(world *World) error {
-	id := world.registry.Create()
+	id := world.createEntity()
 	if c.target != nil {
+		previous := *c.target
+		world.record(func() { *c.target = previous })
 		*c.target = id
 	}
 	return nil
@@ -53,41 +56,33 @@ func (c destroyEntityCommand) Apply(world *World) error {
 	if c.entity.IsZero() {
 		return fmt.Errorf("ecs: destroy zero entity")
 	}
-	if !world.registry.Destroy(c.entity) {
-		return fmt.Errorf("ecs: destroy stale entity %v", c.entity)
+	id, err := world.resolve(c.entity)
+	if err != nil {
+		return err
 	}
-	return nil
+	return world.destroySubtree(id)
 }
 
 func (c addComponentCommand) Apply(world *World) error {
 	if c.entity.IsZero() {
 		return fmt.Errorf("ecs: add component to zero entity")
 	}
-	store, err := world.storage.View(c.component)
+	id, err := world.resolve(c.entity)
 	if err != nil {
 		return err
 	}
-	writable, ok := store.(ComponentStore)
-	if !ok {
-		return fmt.Errorf("ecs: component %s is not writable", c.component)
-	}
-	return writable.Set(c.entity, c.value)
+	return world.setComponent(id, c.component, c.value)
 }
 
 func (c removeComponentCommand) Apply(world *World) error {
 	if c.entity.IsZero() {
 		return fmt.Errorf("ecs: remove component from zero entity")
 	}
-	store, err := world.storage.View(c.component)
+	id, err := world.resolve(c.entity)
 	if err != nil {
 		return err
 	}
-	writable, ok := store.(ComponentStore)
-	if !ok {
-		return fmt.Errorf("ecs: component %s is not writable", c.component)
-	}
-	writable.Remove(c.entity)
-	return nil
+	return world.removeComponent(id, c.component)
 }
 
 var (
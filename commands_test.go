@@ -64,3 +64,119 @@ func TestAddRemoveComponentCommands(t *testing.T) {
 		t.Fatalf("component should be removed")
 	}
 }
+
+type releasingStrategy struct {
+	released *[]ecs.EntityID
+}
+
+func (s releasingStrategy) Name() string { return "releasing" }
+
+func (s releasingStrategy) NewStore(t ecs.ComponentType) ecs.ComponentStore {
+	return &releasingStore{ComponentStore: ecsstorage.NewDenseStrategy().NewStore(t), released: s.released}
+}
+
+type releasingStore struct {
+	ecs.ComponentStore
+	released *[]ecs.EntityID
+}
+
+func (s *releasingStore) ReleaseEntity(id ecs.EntityID) {
+	*s.released = append(*s.released, id)
+}
+
+func TestDestroyEntityCascadesToComponents(t *testing.T) {
+	world := ecs.NewWorld()
+	var released []ecs.EntityID
+	if err := world.RegisterComponent("dense", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register dense: %v", err)
+	}
+	if err := world.RegisterComponent("shared", ecsstorage.NewSharedStrategy()); err != nil {
+		t.Fatalf("register shared: %v", err)
+	}
+	if err := world.RegisterComponent("handle", releasingStrategy{released: &released}); err != nil {
+		t.Fatalf("register handle: %v", err)
+	}
+
+	doomed := world.Registry().Create()
+	survivor := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(doomed, "dense", 1),
+		ecs.NewAddComponentCommand(doomed, "shared", "base"),
+		ecs.NewAddComponentCommand(doomed, "handle", 7),
+		ecs.NewAddComponentCommand(survivor, "shared", "base"),
+		ecs.NewDestroyEntityCommand(doomed),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	for _, typ := range []ecs.ComponentType{"dense", "shared", "handle"} {
+		view, _ := world.ViewComponent(typ)
+		if view.Has(doomed) {
+			t.Fatalf("%s still holds destroyed entity", typ)
+		}
+		view.Iterate(func(id ecs.EntityID, _ any) bool {
+			if id == doomed {
+				t.Fatalf("%s iterates destroyed entity", typ)
+			}
+			return true
+		})
+	}
+	shared, _ := world.ViewComponent("shared")
+	if shared.Len() != 1 {
+		t.Fatalf("expected shared store to keep only the survivor, got %d", shared.Len())
+	}
+	if len(released) != 1 || released[0] != doomed {
+		t.Fatalf("expected release hook for destroyed entity, got %v", released)
+	}
+
+	if err := world.ApplyCommands([]ecs.Command{ecs.NewDestroyEntityCommand(survivor)}); err != nil {
+		t.Fatalf("destroy survivor: %v", err)
+	}
+	if len(released) != 1 {
+		t.Fatalf("release hook must skip entities the store never held, got %v", released)
+	}
+}
+
+// plainProvider hides the default provider's optional interfaces.
+type plainProvider struct {
+	ecs.StorageProvider
+}
+
+func TestDestroyEntityWithoutComponentLister(t *testing.T) {
+	world := ecs.NewWorld(ecs.WithStorageProvider(plainProvider{ecs.NewWorld().Storage()}))
+	if err := world.RegisterComponent("dense", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	id := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(id, "dense", 1),
+		ecs.NewDestroyEntityCommand(id),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	view, _ := world.ViewComponent("dense")
+	if view.Has(id) || view.Len() != 0 {
+		t.Fatalf("expected destroy to sweep components registered through the world")
+	}
+}
+
+func TestDestroyStaleEntityLeavesComponents(t *testing.T) {
+	world := ecs.NewWorld()
+	if err := world.RegisterComponent("dense", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	id := world.Registry().Create()
+	if err := ecs.NewAddComponentCommand(id, "dense", 1).Apply(world); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	stale := ecs.EntityIDFromParts(id.Index(), id.Generation()+1)
+	if err := ecs.NewDestroyEntityCommand(stale).Apply(world); err == nil {
+		t.Fatalf("expected stale destroy to fail")
+	}
+	view, _ := world.ViewComponent("dense")
+	if !view.Has(id) {
+		t.Fatalf("live entity lost its component after stale destroy")
+	}
+}
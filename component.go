@@ -0,0 +1,174 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Component is a typed handle for a registered component type. It removes the
+// `any` assertions from store access and lets the compiler check the values a
+// system writes. Handles are plain values and can be shared across worlds that
+// registered the same component.
+type Component[T any] struct {
+	typ ComponentType
+}
+
+// RegisterComponent registers storage for t and binds it to the Go type T.
+// Registering a name already bound to a different Go type fails with
+// ErrComponentTypeMismatch before any storage is created.
+func RegisterComponent[T any](world *World, t ComponentType, strategy StorageStrategy) (Component[T], error) {
+	goType := typeOf[T]()
+	if err := world.types.claim(t, goType, false); err != nil {
+		return Component[T]{}, err
+	}
+	if err := world.RegisterComponent(t, strategy); err != nil {
+		return Component[T]{}, err
+	}
+	if err := world.types.claim(t, goType, true); err != nil {
+		return Component[T]{}, err
+	}
+	return Component[T]{typ: t}, nil
+}
+
+// LookupComponent returns a handle for a component registered elsewhere, binding
+// it to T when the registration was untyped.
+func LookupComponent[T any](world *World, t ComponentType) (Component[T], error) {
+	if _, err := world.ViewComponent(t); err != nil {
+		return Component[T]{}, err
+	}
+	if err := world.types.claim(t, typeOf[T](), true); err != nil {
+		return Component[T]{}, err
+	}
+	return Component[T]{typ: t}, nil
+}
+
+// Type returns the underlying component type name.
+func (c Component[T]) Type() ComponentType {
+	return c.typ
+}
+
+// Has reports whether the entity holds the component.
+func (c Component[T]) Has(world *World, id EntityID) bool {
+	view, err := world.ViewComponent(c.typ)
+	if err != nil {
+		return false
+	}
+	return view.Has(id)
+}
+
+// Get returns the entity's component value.
+func (c Component[T]) Get(world *World, id EntityID) (T, bool) {
+	var zero T
+	view, err := world.ViewComponent(c.typ)
+	if err != nil {
+		return zero, false
+	}
+	raw, ok := view.Get(id)
+	if !ok {
+		return zero, false
+	}
+	value, ok := raw.(T)
+	return value, ok
+}
+
+// Set adds or replaces the component immediately as a one-command batch, so
+// change ticks, hooks and their follow-ups behave as for AddCommand. It takes
+// the world's apply lock; hooks must defer writes instead of calling it.
+func (c Component[T]) Set(world *World, id EntityID, value T) error {
+	return world.ApplyCommands([]Command{c.AddCommand(id, value)})
+}
+
+// Remove deletes the component immediately as a one-command batch, like
+// RemoveCommand, and reports whether the entity held it.
+func (c Component[T]) Remove(world *World, id EntityID) bool {
+	had := c.Has(world, id)
+	if err := world.ApplyCommands([]Command{c.RemoveCommand(id)}); err != nil {
+		return false
+	}
+	return had
+}
+
+// Iterate visits every stored value. Values holding a different Go type, which
+// can only be written through untyped APIs, are skipped.
+func (c Component[T]) Iterate(world *World, fn func(EntityID, T) bool) error {
+	view, err := world.ViewComponent(c.typ)
+	if err != nil {
+		return err
+	}
+	view.Iterate(func(id EntityID, raw any) bool {
+		value, ok := raw.(T)
+		if !ok {
+			return true
+		}
+		return fn(id, value)
+	})
+	return nil
+}
+
+// AddCommand returns a deferred command that adds or replaces the component.
+func (c Component[T]) AddCommand(id EntityID, value T) Command {
+	return NewAddComponentCommand(id, c.typ, value)
+}
+
+// RemoveCommand returns a deferred command that removes the component.
+func (c Component[T]) RemoveCommand(id EntityID) Command {
+	return NewRemoveComponentCommand(id, c.typ)
+}
+
+// From reads the component from a query row.
+func (c Component[T]) From(row *QueryRow) (T, bool) {
+	return QueryValue[T](row, c.typ)
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// componentTypeRegistry records the Go type bound to each typed component.
+type componentTypeRegistry struct {
+	mu    sync.RWMutex
+	types map[ComponentType]reflect.Type
+}
+
+// claim verifies t is unbound or bound to goType, recording the binding when
+// bind is set.
+func (r *componentTypeRegistry) claim(t ComponentType, goType reflect.Type, bind bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.types[t]; ok {
+		if existing != goType {
+			return fmt.Errorf("%w: %s is bound to %s, not %s", ErrComponentTypeMismatch, t, existing, goType)
+		}
+		return nil
+	}
+	if !bind {
+		return nil
+	}
+	if r.types == nil {
+		r.types = make(map[ComponentType]reflect.Type)
+	}
+	r.types[t] = goType
+	return nil
+}
+
+// check rejects values that do not match the Go type bound to t. Unbound
+// components accept any value.
+func (r *componentTypeRegistry) check(t ComponentType, value any) error {
+	r.mu.RLock()
+	goType, ok := r.types[t]
+	r.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if value == nil {
+		if goType.Kind() == reflect.Interface {
+			return nil
+		}
+		return fmt.Errorf("%w: %s expects %s, got nil", ErrComponentTypeMismatch, t, goType)
+	}
+	if actual := reflect.TypeOf(value); !actual.AssignableTo(goType) {
+		return fmt.Errorf("%w: %s expects %s, got %s", ErrComponentTypeMismatch, t, goType, actual)
+	}
+	return nil
+}
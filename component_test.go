@@ -0,0 +1,110 @@
+package ecs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+type typedStats struct {
+	Health int
+}
+
+func TestTypedComponentRoundTrip(t *testing.T) {
+	world := ecs.NewWorld()
+	stats, err := ecs.RegisterComponent[typedStats](world, "Stats", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	a := world.Registry().Create()
+	b := world.Registry().Create()
+	if err := stats.Set(world, a, typedStats{Health: 10}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := world.ApplyCommands([]ecs.Command{stats.AddCommand(b, typedStats{Health: 20})}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if got, ok := stats.Get(world, b); !ok || got.Health != 20 {
+		t.Fatalf("unexpected typed get: %+v ok=%v", got, ok)
+	}
+
+	total := 0
+	if err := stats.Iterate(world, func(_ ecs.EntityID, s typedStats) bool {
+		total += s.Health
+		return true
+	}); err != nil {
+		t.Fatalf("iterate: %v", err)
+	}
+	if total != 30 {
+		t.Fatalf("expected total health 30, got %d", total)
+	}
+
+	if err := world.ApplyCommands([]ecs.Command{stats.RemoveCommand(a)}); err != nil {
+		t.Fatalf("apply remove: %v", err)
+	}
+	if stats.Has(world, a) {
+		t.Fatalf("expected component removed")
+	}
+}
+
+func TestTypedComponentRejectsMismatchedRegistration(t *testing.T) {
+	world := ecs.NewWorld()
+	if _, err := ecs.RegisterComponent[typedStats](world, "Stats", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if _, err := ecs.RegisterComponent[*typedStats](world, "Stats", ecsstorage.NewDenseStrategy()); !errors.Is(err, ecs.ErrComponentTypeMismatch) {
+		t.Fatalf("expected ErrComponentTypeMismatch, got %v", err)
+	}
+	if _, err := ecs.LookupComponent[int](world, "Stats"); !errors.Is(err, ecs.ErrComponentTypeMismatch) {
+		t.Fatalf("expected lookup mismatch, got %v", err)
+	}
+	if _, err := ecs.LookupComponent[typedStats](world, "Stats"); err != nil {
+		t.Fatalf("expected lookup to succeed: %v", err)
+	}
+}
+
+func TestAddComponentCommandChecksBoundType(t *testing.T) {
+	world := ecs.NewWorld()
+	if _, err := ecs.RegisterComponent[typedStats](world, "Stats", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	id := world.Registry().Create()
+	err := ecs.NewAddComponentCommand(id, "Stats", &typedStats{Health: 1}).Apply(world)
+	if !errors.Is(err, ecs.ErrComponentTypeMismatch) {
+		t.Fatalf("expected pointer value to be rejected, got %v", err)
+	}
+}
+
+func TestTypedSetAndRemoveFollowTheCommandPath(t *testing.T) {
+	world := ecs.NewWorld()
+	stats, err := ecs.RegisterComponent[typedStats](world, "Stats", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	var fired []string
+	world.AddComponentHooks("Stats", ecs.ComponentHooks{
+		OnAdd:    func(*ecs.HookContext, ecs.ComponentEvent) { fired = append(fired, "add") },
+		OnRemove: func(*ecs.HookContext, ecs.ComponentEvent) { fired = append(fired, "remove") },
+	})
+
+	id := world.Registry().Create()
+	if err := stats.Set(world, id, typedStats{Health: 1}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if _, ok := world.ComponentTicks("Stats", id); !ok {
+		t.Fatalf("expected Set to stamp change ticks")
+	}
+	if !stats.Remove(world, id) || stats.Remove(world, id) {
+		t.Fatalf("expected Remove to report the component once")
+	}
+	if _, ok := world.ComponentTicks("Stats", id); ok {
+		t.Fatalf("expected Remove to forget change ticks")
+	}
+	if len(fired) != 2 || fired[0] != "add" || fired[1] != "remove" {
+		t.Fatalf("unexpected hooks %v", fired)
+	}
+}
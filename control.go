@@ -0,0 +1,160 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+)
+
+// UnregisterWorkGroup removes a group and releases the component, resource and
+// event ownership it claimed, so another group may take it over. A SpanTicks
+// group still in flight finishes first: its commands apply, or are dropped
+// under ShutdownDiscardCommands, before its shutdown callbacks run.
+func (s *basicScheduler) UnregisterWorkGroup(id WorkGroupID) error {
+	return s.control(id, "", func(state *workGroupState, _ int) {
+		s.unregisterLocked(state)
+	})
+}
+
+// PauseWorkGroup stops a group from running without releasing its claims.
+func (s *basicScheduler) PauseWorkGroup(id WorkGroupID) error {
+	return s.control(id, "", func(state *workGroupState, _ int) {
+		state.paused = true
+	})
+}
+
+// ResumeWorkGroup lets a paused group run again.
+func (s *basicScheduler) ResumeWorkGroup(id WorkGroupID) error {
+	return s.control(id, "", func(state *workGroupState, _ int) {
+		state.paused = false
+	})
+}
+
+// PauseSystem skips one system of a group until it is resumed.
+func (s *basicScheduler) PauseSystem(group WorkGroupID, system string) error {
+	return s.setSystemPaused(group, system, true)
+}
+
+// ResumeSystem lets a paused system run again.
+func (s *basicScheduler) ResumeSystem(group WorkGroupID, system string) error {
+	return s.setSystemPaused(group, system, false)
+}
+
+func (s *basicScheduler) setSystemPaused(group WorkGroupID, system string, paused bool) error {
+	if system == "" {
+		return fmt.Errorf("ecs: pause or resume requires a system name")
+	}
+	return s.control(group, system, func(state *workGroupState, idx int) {
+		state.systemPaused[idx] = paused
+	})
+}
+
+// control validates the target and applies change immediately, or at the next
+// tick boundary when a tick is in progress so running groups never observe it.
+func (s *basicScheduler) control(id WorkGroupID, system string, change func(state *workGroupState, idx int)) error {
+	s.mu.Lock()
+	state, idx, err := s.lookupLocked(id, system)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	apply := func() {
+		// A queued change may target a group unregistered in the meantime.
+		if s.groupStates[id] == state {
+			change(state, idx)
+		}
+	}
+	if s.ticking {
+		s.pendingControl = append(s.pendingControl, apply)
+		s.mu.Unlock()
+		return nil
+	}
+	apply()
+	detached := s.takeDetachedLocked()
+	world := s.world
+	s.mu.Unlock()
+	return shutdownDetached(context.Background(), detached, world)
+}
+
+// lookupLocked resolves a group and, when system is non-empty, the index of
+// the named system within it.
+func (s *basicScheduler) lookupLocked(id WorkGroupID, system string) (*workGroupState, int, error) {
+	state, ok := s.groupStates[id]
+	if !ok {
+		return nil, -1, fmt.Errorf("ecs: work group %s not registered", id)
+	}
+	if system == "" {
+		return state, -1, nil
+	}
+	for idx, sys := range state.systems {
+		if sys.Descriptor().Name == system {
+			return state, idx, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("ecs: work group %s has no system %s", id, system)
+}
+
+// applyPendingControlLocked runs changes requested during the previous tick.
+func (s *basicScheduler) applyPendingControlLocked() {
+	for _, apply := range s.pendingControl {
+		apply()
+	}
+	s.pendingControl = nil
+}
+
+func (s *basicScheduler) unregisterLocked(state *workGroupState) {
+	delete(s.groupStates, state.id)
+	s.detached = append(s.detached, state)
+	for i, id := range s.registrationOrder {
+		if id == state.id {
+			s.registrationOrder = append(s.registrationOrder[:i], s.registrationOrder[i+1:]...)
+			break
+		}
+	}
+	for comp, owner := range s.componentOwners {
+		if owner == state.id {
+			delete(s.componentOwners, comp)
+		}
+	}
+	for res, owner := range s.resourceOwners {
+		if owner == state.id {
+			delete(s.resourceOwners, res)
+		}
+	}
+	for res, readers := range s.resourceReaders {
+		delete(readers, state.id)
+		if len(readers) == 0 {
+			delete(s.resourceReaders, res)
+		}
+	}
+	for name, owner := range s.eventOwners {
+		if owner == state.id {
+			delete(s.eventOwners, name)
+		}
+	}
+	// Removing a group only drops constraints, so this cannot form a cycle.
+	if err := s.rebuildOrder(); err != nil {
+		s.logger.Error("work group order rebuild failed", "group", string(state.id), "err", err)
+	}
+}
+
+// NewUnregisterWorkGroupCommand defers UnregisterWorkGroup to command
+// application, letting a system unload a group, including its own.
+func NewUnregisterWorkGroupCommand(s Scheduler, id WorkGroupID) Command {
+	return schedulerCommand(func() error { return s.UnregisterWorkGroup(id) })
+}
+
+// NewPauseWorkGroupCommand defers PauseWorkGroup to command application.
+func NewPauseWorkGroupCommand(s Scheduler, id WorkGroupID) Command {
+	return schedulerCommand(func() error { return s.PauseWorkGroup(id) })
+}
+
+// NewResumeWorkGroupCommand defers ResumeWorkGroup to command application.
+func NewResumeWorkGroupCommand(s Scheduler, id WorkGroupID) Command {
+	return schedulerCommand(func() error { return s.ResumeWorkGroup(id) })
+}
+
+type schedulerCommand func() error
+
+func (c schedulerCommand) Apply(*World) error { return c() }
+
+var _ Command = schedulerCommand(nil)
@@ -0,0 +1,148 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+func TestUnregisterWorkGroupReleasesOwnership(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	var order []string
+	claims := ecs.SystemDescriptor{
+		Writes:    []ecs.ComponentType{"Position"},
+		Resources: []ecs.ResourceAccess{{Name: "arena", Mode: ecs.AccessModeWrite}},
+		Events:    []ecs.EventAccess{{Name: "round", Mode: ecs.AccessModeWrite}},
+	}
+	deathmatch := &testSystem{name: "deathmatch", desc: claims, executed: &order}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "deathmatch", Systems: []ecs.System{deathmatch}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctf := &testSystem{name: "ctf", desc: claims, executed: &order}
+	ctfGroup := ecs.WorkGroupConfig{ID: "ctf", Systems: []ecs.System{ctf}}
+	if _, err := scheduler.RegisterWorkGroup(ctfGroup); !errors.Is(err, ecs.ErrDuplicateEventWriteAccess) {
+		t.Fatalf("expected ownership conflict while deathmatch is loaded, got %v", err)
+	}
+	if err := scheduler.UnregisterWorkGroup("deathmatch"); err != nil {
+		t.Fatalf("unregister: %v", err)
+	}
+	if err := scheduler.UnregisterWorkGroup("deathmatch"); err == nil {
+		t.Fatalf("expected unknown group to be rejected")
+	}
+	if _, err := scheduler.RegisterWorkGroup(ctfGroup); err != nil {
+		t.Fatalf("expected released claims to be available: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if len(order) != 1 || order[0] != "ctf" {
+		t.Fatalf("unexpected execution %v", order)
+	}
+}
+
+func TestDeferredUnregisterTakesEffectNextTick(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	var order []string
+	intro := &testSystem{
+		name:     "intro",
+		executed: &order,
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			ctx.Defer(ecs.NewUnregisterWorkGroupCommand(scheduler, "intro"))
+		},
+	}
+	gameplay := &testSystem{name: "gameplay", executed: &order}
+	for _, cfg := range []ecs.WorkGroupConfig{
+		{ID: "intro", Systems: []ecs.System{intro}},
+		{ID: "gameplay", Systems: []ecs.System{gameplay}},
+	} {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+	if err := scheduler.Run(context.Background(), 3, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	want := []string{"intro", "gameplay", "gameplay", "gameplay"}
+	if len(order) != len(want) {
+		t.Fatalf("unexpected execution %v", order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("unexpected execution %v", order)
+		}
+	}
+}
+
+func TestPauseAndResumeGroupsAndSystems(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	counts := map[string]int{}
+	count := func(name string) *testSystem {
+		return &testSystem{name: name, deferCmd: func(ecs.ExecutionContext) { counts[name]++ }}
+	}
+	menu := &testSystem{
+		name: "menu",
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			counts["menu"]++
+			if ctx.TickIndex() == 0 {
+				// Requested mid-tick: world still runs this tick.
+				if err := scheduler.PauseWorkGroup("world"); err != nil {
+					t.Errorf("pause: %v", err)
+				}
+			}
+		},
+	}
+	for _, cfg := range []ecs.WorkGroupConfig{
+		{ID: "ui", Systems: []ecs.System{menu}},
+		{ID: "world", Systems: []ecs.System{count("physics"), count("ai")}},
+	} {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+
+	if err := scheduler.Run(context.Background(), 2, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if counts["physics"] != 1 || counts["menu"] != 2 {
+		t.Fatalf("expected world to pause after the first tick, got %v", counts)
+	}
+
+	if err := scheduler.ResumeWorkGroup("world"); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if err := scheduler.PauseSystem("world", "ai"); err != nil {
+		t.Fatalf("pause system: %v", err)
+	}
+	if err := scheduler.PauseSystem("world", "missing"); err == nil {
+		t.Fatalf("expected unknown system to be rejected")
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if counts["physics"] != 2 || counts["ai"] != 1 {
+		t.Fatalf("expected only physics to resume, got %v", counts)
+	}
+
+	if err := scheduler.ResumeSystem("world", "ai"); err != nil {
+		t.Fatalf("resume system: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if counts["ai"] != 2 {
+		t.Fatalf("expected ai to resume, got %v", counts)
+	}
+}
@@ -165,31 +165,28 @@ func ExampleSharedStats() {
 
 	// Spawn 100 zombies - they all share the SAME GameStats instance
 	for i := 0; i < 100; i++ {
-		var zombieID ecs.EntityID
-		cmds.Push(ecs.NewCreateEntityCommand(&zombieID))
-		cmds.Push(ecs.NewAddComponentCommand(zombieID, "GameStats", zombieStats))
-		cmds.Push(ecs.NewAddComponentCommand(zombieID, "Position", Position{
-			X: float64(i * 10),
-			Y: float64(i % 10),
-		}))
+		spawn, _ := ecs.NewSpawnCommand(ecs.Bundle{
+			{Type: "GameStats", Value: zombieStats},
+			{Type: "Position", Value: Position{X: float64(i * 10), Y: float64(i % 10)}},
+		})
+		cmds.Push(spawn)
 	}
 
 	// Spawn 50 miners - they all share the SAME GameStats instance
 	for i := 0; i < 50; i++ {
-		var minerID ecs.EntityID
-		cmds.Push(ecs.NewCreateEntityCommand(&minerID))
-		cmds.Push(ecs.NewAddComponentCommand(minerID, "GameStats", minerStats))
-		cmds.Push(ecs.NewAddComponentCommand(minerID, "Position", Position{
-			X: float64(i * 15),
-			Y: 100.0,
-		}))
+		spawn, _ := ecs.NewSpawnCommand(ecs.Bundle{
+			{Type: "GameStats", Value: minerStats},
+			{Type: "Position", Value: Position{X: float64(i * 15), Y: 100.0}},
+		})
+		cmds.Push(spawn)
 	}
 
 	// Spawn 1 boss with unique stats
-	var bossID ecs.EntityID
-	cmds.Push(ecs.NewCreateEntityCommand(&bossID))
-	cmds.Push(ecs.NewAddComponentCommand(bossID, "GameStats", bossStats))
-	cmds.Push(ecs.NewAddComponentCommand(bossID, "Position", Position{X: 500, Y: 500}))
+	spawnBoss, _ := ecs.NewSpawnCommand(ecs.Bundle{
+		{Type: "GameStats", Value: bossStats},
+		{Type: "Position", Value: Position{X: 500, Y: 500}},
+	})
+	cmds.Push(spawnBoss)
 
 	// Apply all entity creation commands
 	world.ApplyCommands(cmds.Drain())
@@ -2,6 +2,8 @@ package game
 
 import (
 	"time"
+
+	"github.com/DangerosoDavo/ecs"
 )
 
 // BaseStats represents the immutable base statistics for an entity archetype.
@@ -23,6 +25,14 @@ type CurrentStats struct {
 	IsDead        bool
 }
 
+// EntityDiedEvent names the event channel HealthSystem emits EntityDied on.
+const EntityDiedEvent = "EntityDied"
+
+// EntityDied is emitted once when an entity's health reaches zero.
+type EntityDied struct {
+	Entity ecs.EntityID
+}
+
 // StatModifier represents a time-limited modification to stats (buff or debuff).
 type StatModifier struct {
 	Type       ModifierType
@@ -35,6 +35,9 @@ func ExampleStatsPattern() {
 	// Position is unique per entity
 	world.RegisterComponent("Position", ecsstorage.NewDenseStrategy())
 
+	// HealthSystem announces deaths on this channel for later systems to read
+	ecs.RegisterEvent[EntityDied](world, EntityDiedEvent)
+
 	// Create scheduler with systems
 	scheduler, err := ecs.NewScheduler(world)
 	if err != nil {
@@ -16,48 +16,33 @@ func (HealthSystem) Descriptor() ecs.SystemDescriptor {
 		Name:         "health",
 		Reads:        []ecs.ComponentType{"BaseStats", "StatModifiers"},
 		Writes:       []ecs.ComponentType{"CurrentStats"},
+		Events:       []ecs.EventAccess{{Name: EntityDiedEvent, Mode: ecs.AccessModeWrite}},
 		RunEvery:     ecs.TickInterval{Every: 1},
 		AsyncAllowed: false,
 	}
 }
 
-func (HealthSystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
-	baseStatsView, err := exec.World().ViewComponent("BaseStats")
-	if err != nil {
-		return ecs.SystemResult{Err: err}
-	}
-
-	currentStatsView, err := exec.World().ViewComponent("CurrentStats")
-	if err != nil {
-		return ecs.SystemResult{Err: err}
-	}
-
-	modifiersView, _ := exec.World().ViewComponent("StatModifiers")
-
-	// Iterate through entities with current stats
-	currentStatsView.Iterate(func(id ecs.EntityID, component any) bool {
-		current := component.(CurrentStats)
+// healthQuery joins the components HealthSystem needs; StatModifiers is optional.
+var healthQuery = ecs.MustQuery(ecs.QueryConfig{
+	With:     []ecs.ComponentType{"CurrentStats", "BaseStats"},
+	Optional: []ecs.ComponentType{"StatModifiers"},
+	Writes:   []ecs.ComponentType{"CurrentStats"},
+})
 
+func (HealthSystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	// Death notifications are optional; worlds without the channel skip them.
+	died, diedErr := ecs.LookupEvent[EntityDied](exec.World(), EntityDiedEvent)
+	err := healthQuery.Each(exec.World(), func(row *ecs.QueryRow) bool {
+		id := row.Entity()
+		current, _ := ecs.QueryValue[CurrentStats](row, "CurrentStats")
 		if current.IsDead {
 			return true // skip dead entities
 		}
-
-		// Get base stats
-		baseComponent, hasBase := baseStatsView.Get(id)
-		if !hasBase {
-			return true
-		}
-		base := baseComponent.(BaseStats)
-
-		// Get modifiers if available
-		var mods *StatModifiers
-		if modComponent, hasMods := modifiersView.Get(id); hasMods {
-			m := modComponent.(StatModifiers)
-			mods = &m
-		}
+		before := current
+		base, _ := ecs.QueryValue[BaseStats](row, "BaseStats")
 
 		// Apply health regeneration from modifiers
-		if mods != nil {
+		if mods, ok := ecs.QueryValue[StatModifiers](row, "StatModifiers"); ok {
 			for _, mod := range mods.Modifiers {
 				if mod.Type == ModifierTypeHealthRegen {
 					current.CurrentHealth += int(mod.Value)
@@ -73,14 +58,19 @@ func (HealthSystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.Syst
 			current.IsDead = true
 			current.CurrentHealth = 0
 			exec.Logger().Info("entity died", "entity", id)
+			if diedErr == nil {
+				_ = died.Emit(exec, EntityDied{Entity: id})
+			}
 		}
 
-		// Update current stats
-		exec.Defer(ecs.NewAddComponentCommand(id, "CurrentStats", current))
+		// Update current stats only when they moved, so change detection stays quiet
+		if current != before {
+			exec.Defer(ecs.NewAddComponentCommand(id, "CurrentStats", current))
+		}
 		return true
 	})
 
-	return ecs.SystemResult{}
+	return ecs.SystemResult{Err: err}
 }
 
 // CombatSystem handles damage calculation using base stats and modifiers.
@@ -263,23 +253,21 @@ func (StatsDisplaySystem) Descriptor() ecs.SystemDescriptor {
 	}
 }
 
-func (StatsDisplaySystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
-	baseStatsView, _ := exec.World().ViewComponent("BaseStats")
-	currentStatsView, _ := exec.World().ViewComponent("CurrentStats")
-	modifiersView, _ := exec.World().ViewComponent("StatModifiers")
-
-	currentStatsView.Iterate(func(id ecs.EntityID, component any) bool {
-		current := component.(CurrentStats)
+// statsDisplayQuery fetches everything StatsDisplaySystem logs, limited to
+// entities whose CurrentStats changed since the previous display.
+var statsDisplayQuery = ecs.MustQuery(ecs.QueryConfig{
+	With:     []ecs.ComponentType{"CurrentStats", "BaseStats"},
+	Optional: []ecs.ComponentType{"StatModifiers"},
+	Changed:  []ecs.ComponentType{"CurrentStats"},
+})
 
-		baseComponent, hasBase := baseStatsView.Get(id)
-		if !hasBase {
-			return true
-		}
-		base := baseComponent.(BaseStats)
+func (StatsDisplaySystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	err := statsDisplayQuery.EachChanged(exec, func(row *ecs.QueryRow) bool {
+		current, _ := ecs.QueryValue[CurrentStats](row, "CurrentStats")
+		base, _ := ecs.QueryValue[BaseStats](row, "BaseStats")
 
 		var mods *StatModifiers
-		if modComponent, hasMods := modifiersView.Get(id); hasMods {
-			m := modComponent.(StatModifiers)
+		if m, ok := ecs.QueryValue[StatModifiers](row, "StatModifiers"); ok {
 			mods = &m
 		}
 
@@ -294,7 +282,7 @@ func (StatsDisplaySystem) Run(ctx context.Context, exec ecs.ExecutionContext) ec
 		}
 
 		exec.Logger().Info("entity stats",
-			"entity", id,
+			"entity", row.Entity(),
 			"health", current.CurrentHealth,
 			"max_health", base.MaxHealth,
 			"attack", effectiveAttack,
@@ -310,5 +298,5 @@ func (StatsDisplaySystem) Run(ctx context.Context, exec ecs.ExecutionContext) ec
 		return true
 	})
 
-	return ecs.SystemResult{}
+	return ecs.SystemResult{Err: err}
 }
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// diffEntry compares one latency metric between a base and head run.
+type diffEntry struct {
+	Kind       string  `json:"kind"`
+	Name       string  `json:"name"`
+	Base       float64 `json:"base_ms"`
+	Head       float64 `json:"head_ms"`
+	DeltaPct   float64 `json:"delta_pct"`
+	Regression bool    `json:"regression"`
+}
+
+type diffReport struct {
+	Base         string      `json:"base"`
+	Head         string      `json:"head"`
+	Metric       string      `json:"metric"`
+	ThresholdPct float64     `json:"threshold_pct"`
+	MinDeltaMS   float64     `json:"min_delta_ms"`
+	Regressions  int         `json:"regressions"`
+	Entries      []diffEntry `json:"entries"`
+}
+
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	threshold := fs.Float64("threshold", 10, "regression threshold in percent")
+	minDelta := fs.Float64("min-delta-ms", 0.01, "ignore regressions smaller than this many milliseconds")
+	metric := fs.String("metric", "p90", "metric to compare: p50, p90, p99, max or mean")
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("diff takes a base and a head input\n%s", usage)
+	}
+	pick, ok := metricPickers[*metric]
+	if !ok {
+		return fmt.Errorf("unknown metric %q", *metric)
+	}
+	base, err := loadSummary(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	head, err := loadSummary(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	report := compareSummaries(base, head, *metric, pick, *threshold, *minDelta)
+	switch *format {
+	case "json":
+		err = writeJSON(stdout, report)
+	case "text":
+		err = writeDiffText(stdout, report)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Regressions > 0 {
+		return errRegression
+	}
+	return nil
+}
+
+var metricPickers = map[string]func(latency) float64{
+	"p50":  func(l latency) float64 { return l.P50 },
+	"p90":  func(l latency) float64 { return l.P90 },
+	"p99":  func(l latency) float64 { return l.P99 },
+	"max":  func(l latency) float64 { return l.Max },
+	"mean": func(l latency) float64 { return l.Mean },
+}
+
+// compareSummaries pairs entries present in both runs. A regression needs to
+// exceed both the relative threshold and the absolute minimum delta.
+func compareSummaries(base, head *summary, metric string, pick func(latency) float64, threshold, minDelta float64) diffReport {
+	report := diffReport{
+		Base:         base.Source,
+		Head:         head.Source,
+		Metric:       metric,
+		ThresholdPct: threshold,
+		MinDeltaMS:   minDelta,
+		Entries:      []diffEntry{},
+	}
+	baseIndex := indexLatencies(base)
+	headIndex := indexLatencies(head)
+	for _, key := range sortedKeys(headIndex) {
+		before, ok := baseIndex[key]
+		if !ok {
+			continue
+		}
+		after := headIndex[key]
+		entry := diffEntry{Kind: key.kind, Name: key.name, Base: pick(before), Head: pick(after)}
+		if entry.Base > 0 {
+			entry.DeltaPct = (entry.Head - entry.Base) / entry.Base * 100
+		}
+		entry.Regression = entry.DeltaPct > threshold && entry.Head-entry.Base >= minDelta
+		if entry.Regression {
+			report.Regressions++
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	return report
+}
+
+type latencyKey struct {
+	kind string
+	name string
+}
+
+func indexLatencies(sum *summary) map[latencyKey]latency {
+	out := make(map[latencyKey]latency)
+	if sum.Tick != nil {
+		out[latencyKey{"tick", sum.Tick.Name}] = *sum.Tick
+	}
+	for _, l := range sum.Groups {
+		out[latencyKey{"group", l.Name}] = l
+	}
+	for _, l := range sum.Systems {
+		out[latencyKey{"system", l.Name}] = l
+	}
+	for _, l := range sum.AsyncWaits {
+		out[latencyKey{"async_wait", l.Name}] = l
+	}
+	return out
+}
+
+var kindOrder = map[string]int{"tick": 0, "group": 1, "system": 2, "async_wait": 3}
+
+func sortedKeys(index map[latencyKey]latency) []latencyKey {
+	keys := make([]latencyKey, 0, len(index))
+	for key := range index {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return kindOrder[keys[i].kind] < kindOrder[keys[j].kind]
+		}
+		return keys[i].name < keys[j].name
+	})
+	return keys
+}
+
+func writeDiffText(w io.Writer, report diffReport) error {
+	fmt.Fprintf(w, "base: %s\nhead: %s\nmetric: %s, threshold: %.1f%%\n\n", report.Base, report.Head, report.Metric, report.ThresholdPct)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAME\tBASE ms\tHEAD ms\tDELTA\t")
+	for _, entry := range report.Entries {
+		flag := ""
+		if entry.Regression {
+			flag = "REGRESSION"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.3f\t%.3f\t%+.1f%%\t%s\n", entry.Kind, entry.Name, entry.Base, entry.Head, entry.DeltaPct, flag)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\n%d regression(s)\n", report.Regressions)
+	return nil
+}
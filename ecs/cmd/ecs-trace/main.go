@@ -1,7 +1,61 @@
+// Command ecs-trace captures, summarizes and compares scheduler traces.
+//
+//	ecs-trace record [-workload sample] [-ticks 300] [-o trace.out] [-spans spans.jsonl]
+//	ecs-trace summarize [-format text|json] <trace.out|spans.jsonl|summary.json>
+//	ecs-trace diff [-threshold 10] [-metric p90] [-format text|json] <base> <head>
+//
+// summarize accepts Go execution traces written by RunWithTrace, SigNoz span
+// output and JSON structured-logging output. diff exits with status 1 when a
+// regression exceeds the threshold, so CI can gate on it.
 package main
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errRegression signals that diff found regressions; it maps to exit status 1.
+var errRegression = errors.New("regressions above threshold")
 
 func main() {
-	fmt.Println("ecs-trace CLI placeholder: implementation arrives in Stage 3")
+	err := run(os.Args[1:], os.Stdout)
+	switch {
+	case err == nil:
+	case errors.Is(err, errRegression):
+		os.Exit(1)
+	default:
+		fmt.Fprintln(os.Stderr, "ecs-trace:", err)
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageError()
+	}
+	switch args[0] {
+	case "record":
+		return runRecord(args[1:], stdout)
+	case "summarize":
+		return runSummarize(args[1:], stdout)
+	case "diff":
+		return runDiff(args[1:], stdout)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+const usage = `usage:
+  ecs-trace record [-workload name] [-ticks n] [-dt d] [-o trace.out] [-spans spans.jsonl]
+  ecs-trace summarize [-format text|json] <file>
+  ecs-trace diff [-threshold pct] [-metric p50|p90|p99|max|mean] [-format text|json] <base> <head>
+`
+
+func usageError() error {
+	return fmt.Errorf("missing command\n%s", usage)
 }
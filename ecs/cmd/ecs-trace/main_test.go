@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeReadsRecordedTrace(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "trace.out")
+	var log strings.Builder
+	if err := run([]string{"record", "-ticks", "20", "-o", out}, &log); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	sum, err := loadSummary(out)
+	if err != nil {
+		t.Fatalf("summarize: %v", err)
+	}
+	if sum.Format != "go-trace" || sum.Ticks != 20 || sum.Tick == nil || sum.Tick.Max <= 0 {
+		t.Fatalf("unexpected tick stats: format=%q ticks=%d tick=%+v", sum.Format, sum.Ticks, sum.Tick)
+	}
+	if len(sum.Groups) == 0 || len(sum.Systems) == 0 {
+		t.Fatalf("expected groups and systems, got %+v / %+v", sum.Groups, sum.Systems)
+	}
+	for _, system := range sum.Systems {
+		if group, _, ok := strings.Cut(system.Name, "/"); !ok || group == "" {
+			t.Fatalf("system %q is not nested under a work group", system.Name)
+		}
+	}
+}
+
+func TestParseJSONLinesReadsSpansAndLogs(t *testing.T) {
+	spans := `{"name":"workgroup:physics","duration_ms":2,"attributes":{"work_group_id":"physics"}}
+{"name":"system:move","parent":"workgroup:physics","duration_ms":1.5,"attributes":{"work_group_id":"physics","system":"move"}}`
+	samples, format, err := parseJSONLines(strings.NewReader(spans))
+	if err != nil || format != "spans" {
+		t.Fatalf("parse spans: format=%q err=%v", format, err)
+	}
+	if sum := samples.summarize("spans", format); len(sum.Systems) != 1 || sum.Systems[0].Name != "physics/move" {
+		t.Fatalf("unexpected span systems: %+v", sum.Systems)
+	}
+
+	logs := `INFO {"work_group_id":"physics","duration_ms":3,"systems":[{"name":"move","status":"executed","duration_ms":2.5},{"name":"idle","status":"skipped","duration_ms":0}]}`
+	samples, format, err = parseJSONLines(strings.NewReader(logs))
+	if err != nil || format != "logs" {
+		t.Fatalf("parse logs: format=%q err=%v", format, err)
+	}
+	if sum := samples.summarize("logs", format); len(sum.Systems) != 1 || sum.Systems[0].Max != 2.5 {
+		t.Fatalf("unexpected log systems: %+v", sum.Systems)
+	}
+
+	if _, _, err := parseJSONLines(strings.NewReader("not json")); err == nil {
+		t.Fatalf("expected an error for input without records")
+	}
+}
+
+func TestCompareSummariesFlagsRegressions(t *testing.T) {
+	base := &summary{Source: "base", Groups: []latency{{Name: "ai", P90: 1}, {Name: "physics", P90: 4}}}
+	head := &summary{Source: "head", Groups: []latency{{Name: "ai", P90: 1.05}, {Name: "physics", P90: 5}, {Name: "new", P90: 9}}}
+
+	report := compareSummaries(base, head, "p90", metricPickers["p90"], 10, 0.01)
+	if report.Regressions != 1 || len(report.Entries) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if entry := report.Entries[1]; entry.Name != "physics" || !entry.Regression || entry.DeltaPct != 25 {
+		t.Fatalf("unexpected physics entry: %+v", entry)
+	}
+
+	if report := compareSummaries(base, head, "p90", metricPickers["p90"], 10, 2); report.Regressions != 0 {
+		t.Fatalf("expected the absolute floor to suppress small regressions, got %+v", report)
+	}
+}
+
+func TestRunRejectsUnknownCommands(t *testing.T) {
+	var out strings.Builder
+	if err := run([]string{"bogus"}, &out); err == nil || errors.Is(err, errRegression) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+// workload builds a world and the work groups that drive it.
+type workload func(world *ecs.World) ([]ecs.WorkGroupConfig, error)
+
+// workloads lists the sample workloads record can run.
+var workloads = map[string]workload{
+	"sample": sampleWorkload,
+}
+
+func runRecord(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("record", flag.ContinueOnError)
+	name := fs.String("workload", "sample", "workload to run ("+strings.Join(workloadNames(), ", ")+")")
+	ticks := fs.Int("ticks", 300, "number of ticks to run")
+	dt := fs.Duration("dt", time.Second/60, "simulated time per tick")
+	out := fs.String("o", "trace.out", "Go execution trace output file")
+	spans := fs.String("spans", "", "optional SigNoz-style span output file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	build, ok := workloads[*name]
+	if !ok {
+		return fmt.Errorf("unknown workload %q", *name)
+	}
+
+	world := ecs.NewWorld()
+	groups, err := build(world)
+	if err != nil {
+		return fmt.Errorf("build workload %s: %w", *name, err)
+	}
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		return err
+	}
+	instrumentation := ecs.InstrumentationConfig{EnableTrace: true}
+	if *spans != "" {
+		spanFile, err := os.Create(*spans)
+		if err != nil {
+			return err
+		}
+		defer spanFile.Close()
+		instrumentation.Observation = ecs.ObservationSettings{
+			EnableSigNoz:  true,
+			SigNozOptions: &ecs.SigNozOptions{Writer: spanFile, ServiceName: "ecs-trace-" + *name},
+		}
+	}
+	scheduler.Builder().WithAsyncWorkers(2).WithInstrumentation(instrumentation)
+	for _, cfg := range groups {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			return fmt.Errorf("register %s: %w", cfg.ID, err)
+		}
+	}
+
+	traceFile, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer traceFile.Close()
+	ctx := context.Background()
+	start := time.Now()
+	err = scheduler.RunWithTrace(ctx, traceFile, func() error {
+		return scheduler.Run(ctx, *ticks, *dt)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "recorded %d ticks of %s in %s to %s\n", *ticks, *name, time.Since(start).Round(time.Millisecond), *out)
+	return nil
+}
+
+func workloadNames() []string {
+	names := make([]string, 0, len(workloads))
+	for name := range workloads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type position struct{ X, Y float64 }
+
+type velocity struct{ DX, DY float64 }
+
+const sampleEntities = 2000
+
+// sampleWorkload moves entities, steers them and gathers statistics in an
+// async group, which exercises sync, async and command-heavy paths.
+func sampleWorkload(world *ecs.World) ([]ecs.WorkGroupConfig, error) {
+	positions, err := ecs.RegisterComponent[position](world, "Position", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		return nil, err
+	}
+	velocities, err := ecs.RegisterComponent[velocity](world, "Velocity", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < sampleEntities; i++ {
+		id := world.Registry().Create()
+		angle := float64(i) * 0.01
+		if err := positions.Set(world, id, position{X: float64(i), Y: float64(i % 100)}); err != nil {
+			return nil, err
+		}
+		if err := velocities.Set(world, id, velocity{DX: math.Cos(angle), DY: math.Sin(angle)}); err != nil {
+			return nil, err
+		}
+	}
+
+	move := &funcSystem{
+		desc: ecs.SystemDescriptor{Name: "move", Reads: []ecs.ComponentType{"Velocity"}, Writes: []ecs.ComponentType{"Position"}},
+		run: func(exec ecs.ExecutionContext) error {
+			step := exec.TimeDelta().Seconds()
+			return positions.Iterate(exec.World(), func(id ecs.EntityID, p position) bool {
+				if v, ok := velocities.Get(exec.World(), id); ok {
+					exec.Defer(positions.AddCommand(id, position{X: p.X + v.DX*step, Y: p.Y + v.DY*step}))
+				}
+				return true
+			})
+		},
+	}
+	steer := &funcSystem{
+		desc: ecs.SystemDescriptor{Name: "steer", Reads: []ecs.ComponentType{"Position"}, Writes: []ecs.ComponentType{"Velocity"}, RunEvery: ecs.TickInterval{Every: 4}},
+		run: func(exec ecs.ExecutionContext) error {
+			return velocities.Iterate(exec.World(), func(id ecs.EntityID, v velocity) bool {
+				if p, ok := positions.Get(exec.World(), id); ok && (math.Abs(p.X) > 5000 || math.Abs(p.Y) > 5000) {
+					exec.Defer(velocities.AddCommand(id, velocity{DX: -v.DX, DY: -v.DY}))
+				}
+				return true
+			})
+		},
+	}
+	stats := &funcSystem{
+		desc: ecs.SystemDescriptor{Name: "centroid", Reads: []ecs.ComponentType{"Position"}, AsyncAllowed: true},
+		run: func(exec ecs.ExecutionContext) error {
+			var sumX, sumY float64
+			return positions.Iterate(exec.World(), func(_ ecs.EntityID, p position) bool {
+				sumX += p.X
+				sumY += p.Y
+				return true
+			})
+		},
+	}
+
+	return []ecs.WorkGroupConfig{
+		{ID: "physics", Systems: []ecs.System{move}},
+		{ID: "ai", Systems: []ecs.System{steer}, After: []string{"physics"}},
+		{ID: "stats", Mode: ecs.WorkGroupModeAsync, Systems: []ecs.System{stats}},
+	}, nil
+}
+
+type funcSystem struct {
+	desc ecs.SystemDescriptor
+	run  func(exec ecs.ExecutionContext) error
+}
+
+func (s *funcSystem) Descriptor() ecs.SystemDescriptor { return s.desc }
+
+func (s *funcSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	return ecs.SystemResult{Err: s.run(exec)}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/exp/trace"
+)
+
+// latency holds percentile statistics for one span name, in milliseconds.
+type latency struct {
+	Name  string  `json:"name"`
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean_ms"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+// summary is the summarize output and the diff input. Systems are named
+// "<group>/<system>".
+type summary struct {
+	Source     string    `json:"source"`
+	Format     string    `json:"format"`
+	Ticks      int       `json:"ticks"`
+	Tick       *latency  `json:"tick,omitempty"`
+	Groups     []latency `json:"groups"`
+	Systems    []latency `json:"systems"`
+	AsyncWaits []latency `json:"async_waits,omitempty"`
+}
+
+func runSummarize(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("summarize", flag.ContinueOnError)
+	format := fs.String("format", "text", "output format: text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("summarize takes exactly one input file\n%s", usage)
+	}
+	sum, err := loadSummary(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "json":
+		return writeJSON(stdout, sum)
+	case "text":
+		return writeSummaryText(stdout, sum)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+// loadSummary reads a Go execution trace, span or log JSON lines, or a
+// previously written summary.
+func loadSummary(path string) (*summary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte("go 1.")) {
+		samples, err := parseGoTrace(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return samples.summarize(path, "go-trace"), nil
+	}
+
+	var existing summary
+	if err := json.Unmarshal(data, &existing); err == nil && existing.Groups != nil {
+		return &existing, nil
+	}
+	samples, format, err := parseJSONLines(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return samples.summarize(path, format), nil
+}
+
+// samples collects raw durations keyed by span name.
+type samples struct {
+	ticks   []time.Duration
+	groups  map[string][]time.Duration
+	systems map[string][]time.Duration
+	waits   map[string][]time.Duration
+}
+
+func newSamples() *samples {
+	return &samples{
+		groups:  make(map[string][]time.Duration),
+		systems: make(map[string][]time.Duration),
+		waits:   make(map[string][]time.Duration),
+	}
+}
+
+func (s *samples) add(name, group string, d time.Duration) {
+	switch {
+	case name == "ecs.tick":
+		s.ticks = append(s.ticks, d)
+	case strings.HasPrefix(name, "workgroup:"):
+		id := strings.TrimPrefix(name, "workgroup:")
+		s.groups[id] = append(s.groups[id], d)
+	case strings.HasPrefix(name, "system:"):
+		key := group + "/" + strings.TrimPrefix(name, "system:")
+		s.systems[key] = append(s.systems[key], d)
+	case strings.HasPrefix(name, "async_wait:"):
+		id := strings.TrimPrefix(name, "async_wait:")
+		s.waits[id] = append(s.waits[id], d)
+	}
+}
+
+func (s *samples) summarize(source, format string) *summary {
+	sum := &summary{
+		Source:     source,
+		Format:     format,
+		Ticks:      len(s.ticks),
+		Groups:     latencies(s.groups),
+		Systems:    latencies(s.systems),
+		AsyncWaits: latencies(s.waits),
+	}
+	if len(s.ticks) > 0 {
+		tick := computeLatency("tick", s.ticks)
+		sum.Tick = &tick
+	}
+	return sum
+}
+
+type openRegion struct {
+	name  string
+	start trace.Time
+}
+
+// parseGoTrace reads a binary execution trace. Regions nest per goroutine, so
+// a system region belongs to the work group region below it.
+func parseGoTrace(r io.Reader) (*samples, error) {
+	reader, err := trace.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	out := newSamples()
+	stacks := make(map[trace.GoID][]openRegion)
+	tasks := make(map[trace.TaskID]trace.Time)
+	for {
+		ev, err := reader.ReadEvent()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch ev.Kind() {
+		case trace.EventTaskBegin:
+			if task := ev.Task(); task.Type == "ecs.tick" {
+				tasks[task.ID] = ev.Time()
+			}
+		case trace.EventTaskEnd:
+			task := ev.Task()
+			if start, ok := tasks[task.ID]; ok {
+				out.add(task.Type, "", ev.Time().Sub(start))
+				delete(tasks, task.ID)
+			}
+		case trace.EventRegionBegin:
+			g := ev.Goroutine()
+			stacks[g] = append(stacks[g], openRegion{name: ev.Region().Type, start: ev.Time()})
+		case trace.EventRegionEnd:
+			g, name := ev.Goroutine(), ev.Region().Type
+			stack := stacks[g]
+			idx := len(stack) - 1
+			for idx >= 0 && stack[idx].name != name {
+				idx--
+			}
+			if idx < 0 {
+				continue
+			}
+			out.add(name, enclosingGroup(stack[:idx]), ev.Time().Sub(stack[idx].start))
+			stacks[g] = stack[:idx]
+		}
+	}
+}
+
+func enclosingGroup(stack []openRegion) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if id, ok := strings.CutPrefix(stack[i].name, "workgroup:"); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// jsonRecord covers both SigNoz span lines and JSON logging summaries.
+type jsonRecord struct {
+	Name        string         `json:"name"`
+	DurationMS  *float64       `json:"duration_ms"`
+	WorkGroupID string         `json:"work_group_id"`
+	Attributes  map[string]any `json:"attributes"`
+	Systems     []struct {
+		Name       string  `json:"name"`
+		Status     string  `json:"status"`
+		DurationMS float64 `json:"duration_ms"`
+	} `json:"systems"`
+}
+
+// parseJSONLines accepts one JSON object per line, possibly prefixed by a
+// logger; lines that are not span or summary records are ignored.
+func parseJSONLines(r io.Reader) (*samples, string, error) {
+	out := newSamples()
+	format := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		brace := strings.IndexByte(line, '{')
+		if brace < 0 {
+			continue
+		}
+		var rec jsonRecord
+		if err := json.Unmarshal([]byte(line[brace:]), &rec); err != nil || rec.DurationMS == nil {
+			continue
+		}
+		switch {
+		case rec.Name != "":
+			group, _ := rec.Attributes["work_group_id"].(string)
+			out.add(rec.Name, group, msToDuration(*rec.DurationMS))
+			format = "spans"
+		case rec.WorkGroupID != "":
+			out.add("workgroup:"+rec.WorkGroupID, "", msToDuration(*rec.DurationMS))
+			for _, system := range rec.Systems {
+				if system.Status == "skipped" && system.DurationMS == 0 {
+					continue
+				}
+				out.add("system:"+system.Name, rec.WorkGroupID, msToDuration(system.DurationMS))
+			}
+			format = "logs"
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	if format == "" {
+		return nil, "", fmt.Errorf("no trace, span or work group summary records found")
+	}
+	return out, format, nil
+}
+
+func msToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+func latencies(byName map[string][]time.Duration) []latency {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make([]latency, 0, len(names))
+	for _, name := range names {
+		out = append(out, computeLatency(name, byName[name]))
+	}
+	return out
+}
+
+func computeLatency(name string, durations []time.Duration) latency {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return latency{
+		Name:  name,
+		Count: len(sorted),
+		Mean:  millis(total / time.Duration(len(sorted))),
+		P50:   millis(percentile(sorted, 50)),
+		P90:   millis(percentile(sorted, 90)),
+		P99:   millis(percentile(sorted, 99)),
+		Max:   millis(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile uses the nearest-rank method on sorted input.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeSummaryText(w io.Writer, sum *summary) error {
+	fmt.Fprintf(w, "source: %s (%s)", sum.Source, sum.Format)
+	if sum.Ticks > 0 {
+		fmt.Fprintf(w, ", %d ticks", sum.Ticks)
+	}
+	fmt.Fprint(w, "\n\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tNAME\tCOUNT\tMEAN ms\tP50 ms\tP90 ms\tP99 ms\tMAX ms\t")
+	row := func(kind string, l latency) {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t\n", kind, l.Name, l.Count, l.Mean, l.P50, l.P90, l.P99, l.Max)
+	}
+	if sum.Tick != nil {
+		row("tick", *sum.Tick)
+	}
+	for _, l := range sum.Groups {
+		row("group", l)
+	}
+	for _, l := range sum.Systems {
+		row("system", l)
+	}
+	for _, l := range sum.AsyncWaits {
+		row("async_wait", l)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	ecs "github.com/DangerosoDavo/ecs"
+)
+
+// ArchetypeProvider is a world-level storage provider that groups entities with
+// identical component sets into tables with one column per component. Adding or
+// removing a component moves the entity's row to the table for its new set, so
+// iterating several components walks parallel columns instead of joining
+// per-component stores.
+//
+// Component registration ignores the supplied strategy because every component
+// lives in the archetype tables. Install it with ecs.WithStorageProvider.
+type ArchetypeProvider struct {
+	mu        sync.RWMutex
+	views     map[ecs.ComponentType]*archetypeView
+	tables    []*archetypeTable
+	bySig     map[string]*archetypeTable
+	locations map[uint32]archetypeLocation
+	counts    map[ecs.ComponentType]int
+}
+
+// NewArchetypeProvider constructs an empty archetype storage provider.
+func NewArchetypeProvider() *ArchetypeProvider {
+	return &ArchetypeProvider{
+		views:     make(map[ecs.ComponentType]*archetypeView),
+		bySig:     make(map[string]*archetypeTable),
+		locations: make(map[uint32]archetypeLocation),
+		counts:    make(map[ecs.ComponentType]int),
+	}
+}
+
+// archetypeTable stores every entity holding exactly one component set.
+type archetypeTable struct {
+	types    []ecs.ComponentType
+	columns  map[ecs.ComponentType]int
+	entities []ecs.EntityID
+	data     [][]any
+}
+
+type archetypeLocation struct {
+	id    ecs.EntityID
+	table *archetypeTable
+	row   int
+}
+
+// RegisterComponent declares a component column. The strategy is ignored.
+func (p *ArchetypeProvider) RegisterComponent(t ecs.ComponentType, _ ecs.StorageStrategy) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.views[t]; exists {
+		return ecs.ErrComponentAlreadyRegistered
+	}
+	p.views[t] = &archetypeView{provider: p, typ: t}
+	return nil
+}
+
+// View returns a store view for the component backed by the archetype tables.
+func (p *ArchetypeProvider) View(t ecs.ComponentType) (ecs.ComponentView, error) {
+	p.mu.RLock()
+	view, ok := p.views[t]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, ecs.ErrComponentNotRegistered
+	}
+	return view, nil
+}
+
+// Components lists registered component types in name order.
+func (p *ArchetypeProvider) Components() []ecs.ComponentType {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make([]ecs.ComponentType, 0, len(p.views))
+	for t := range p.views {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// RemoveEntity drops the entity's row without moving it through intermediate tables.
+func (p *ArchetypeProvider) RemoveEntity(id ecs.EntityID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if loc, ok := p.lookupLocked(id); ok {
+		p.moveLocked(loc, nil)
+	}
+}
+
+// Apply executes deferred commands in order.
+func (p *ArchetypeProvider) Apply(world *ecs.World, commands []ecs.Command) error {
+	return ecs.ApplyInOrder(world, commands)
+}
+
+// Each visits entities holding every listed component, passing values in the
+// order of types. The values slice is reused between calls. Tables are walked
+// in place under the read lock, so fn may read the provider but must not
+// modify it; queue writes as commands instead.
+func (p *ArchetypeProvider) Each(types []ecs.ComponentType, fn func(id ecs.EntityID, values []any) bool) {
+	if len(types) == 0 || fn == nil {
+		return
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	values := make([]any, len(types))
+	cols := make([]int, len(types))
+	for _, table := range p.tables {
+		if !table.resolveColumns(types, cols) {
+			continue
+		}
+		for row, id := range table.entities {
+			for i, col := range cols {
+				values[i] = table.data[col][row]
+			}
+			if !fn(id, values) {
+				return
+			}
+		}
+	}
+}
+
+// TableCount reports how many distinct component sets have been materialised.
+func (p *ArchetypeProvider) TableCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.tables)
+}
+
+func (t *archetypeTable) resolveColumns(types []ecs.ComponentType, cols []int) bool {
+	for i, typ := range types {
+		col, ok := t.columns[typ]
+		if !ok {
+			return false
+		}
+		cols[i] = col
+	}
+	return true
+}
+
+func (t *archetypeTable) has(typ ecs.ComponentType) bool {
+	_, ok := t.columns[typ]
+	return ok
+}
+
+// appendRow adds an entity copying shared columns from src; missing columns stay nil.
+func (t *archetypeTable) appendRow(id ecs.EntityID, src *archetypeTable, srcRow int) int {
+	row := len(t.entities)
+	t.entities = append(t.entities, id)
+	for col, typ := range t.types {
+		var value any
+		if src != nil {
+			if srcCol, ok := src.columns[typ]; ok {
+				value = src.data[srcCol][srcRow]
+			}
+		}
+		t.data[col] = append(t.data[col], value)
+	}
+	return row
+}
+
+// swapRemove deletes a row, returning the entity moved into its place if any.
+func (t *archetypeTable) swapRemove(row int) (ecs.EntityID, bool) {
+	last := len(t.entities) - 1
+	moved := t.entities[last]
+	t.entities[row] = moved
+	t.entities = t.entities[:last]
+	for col := range t.data {
+		t.data[col][row] = t.data[col][last]
+		t.data[col][last] = nil
+		t.data[col] = t.data[col][:last]
+	}
+	return moved, row != last
+}
+
+func (p *ArchetypeProvider) tableForLocked(types []ecs.ComponentType) *archetypeTable {
+	if len(types) == 0 {
+		return nil
+	}
+	sorted := append([]ecs.ComponentType(nil), types...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	names := make([]string, len(sorted))
+	for i, typ := range sorted {
+		names[i] = string(typ)
+	}
+	sig := strings.Join(names, "\x00")
+	if table, ok := p.bySig[sig]; ok {
+		return table
+	}
+	table := &archetypeTable{
+		types:   sorted,
+		columns: make(map[ecs.ComponentType]int, len(sorted)),
+		data:    make([][]any, len(sorted)),
+	}
+	for i, typ := range sorted {
+		table.columns[typ] = i
+	}
+	p.bySig[sig] = table
+	p.tables = append(p.tables, table)
+	return table
+}
+
+// lookupLocked returns the live location for id, ignoring stale generations.
+func (p *ArchetypeProvider) lookupLocked(id ecs.EntityID) (archetypeLocation, bool) {
+	loc, ok := p.locations[id.Index()]
+	if !ok || loc.id != id {
+		return archetypeLocation{}, false
+	}
+	return loc, true
+}
+
+// moveLocked relocates an entity to dst, dropping columns dst does not hold.
+// A nil dst removes the entity from storage entirely.
+func (p *ArchetypeProvider) moveLocked(loc archetypeLocation, dst *archetypeTable) archetypeLocation {
+	next := archetypeLocation{id: loc.id, table: dst}
+	if dst != nil {
+		next.row = dst.appendRow(loc.id, loc.table, loc.row)
+	}
+	if loc.table != nil {
+		for _, typ := range loc.table.types {
+			p.counts[typ]--
+		}
+		if moved, ok := loc.table.swapRemove(loc.row); ok {
+			movedLoc := p.locations[moved.Index()]
+			movedLoc.row = loc.row
+			p.locations[moved.Index()] = movedLoc
+		}
+	}
+	if dst == nil {
+		delete(p.locations, loc.id.Index())
+		return next
+	}
+	for _, typ := range dst.types {
+		p.counts[typ]++
+	}
+	p.locations[loc.id.Index()] = next
+	return next
+}
+
+func (p *ArchetypeProvider) setLocked(id ecs.EntityID, typ ecs.ComponentType, value any) {
+	loc, ok := p.locations[id.Index()]
+	if ok && loc.id != id {
+		// The slot belongs to an older generation; its data must not leak into the new entity.
+		p.moveLocked(loc, nil)
+		ok = false
+	}
+	if !ok {
+		loc = archetypeLocation{id: id}
+	}
+	if loc.table != nil && loc.table.has(typ) {
+		loc.table.data[loc.table.columns[typ]][loc.row] = value
+		return
+	}
+	types := []ecs.ComponentType{typ}
+	if loc.table != nil {
+		types = append(types, loc.table.types...)
+	}
+	next := p.moveLocked(loc, p.tableForLocked(types))
+	next.table.data[next.table.columns[typ]][next.row] = value
+}
+
+func (p *ArchetypeProvider) removeLocked(id ecs.EntityID, typ ecs.ComponentType) bool {
+	loc, ok := p.lookupLocked(id)
+	if !ok || !loc.table.has(typ) {
+		return false
+	}
+	remaining := make([]ecs.ComponentType, 0, len(loc.table.types)-1)
+	for _, t := range loc.table.types {
+		if t != typ {
+			remaining = append(remaining, t)
+		}
+	}
+	p.moveLocked(loc, p.tableForLocked(remaining))
+	return true
+}
+
+// archetypeView adapts a single component column set to ecs.ComponentStore.
+type archetypeView struct {
+	provider *ArchetypeProvider
+	typ      ecs.ComponentType
+}
+
+func (v *archetypeView) ComponentType() ecs.ComponentType {
+	return v.typ
+}
+
+func (v *archetypeView) Len() int {
+	v.provider.mu.RLock()
+	defer v.provider.mu.RUnlock()
+	return v.provider.counts[v.typ]
+}
+
+func (v *archetypeView) Has(id ecs.EntityID) bool {
+	v.provider.mu.RLock()
+	defer v.provider.mu.RUnlock()
+	loc, ok := v.provider.lookupLocked(id)
+	return ok && loc.table.has(v.typ)
+}
+
+func (v *archetypeView) Get(id ecs.EntityID) (any, bool) {
+	v.provider.mu.RLock()
+	defer v.provider.mu.RUnlock()
+	loc, ok := v.provider.lookupLocked(id)
+	if !ok {
+		return nil, false
+	}
+	col, ok := loc.table.columns[v.typ]
+	if !ok {
+		return nil, false
+	}
+	return loc.table.data[col][loc.row], true
+}
+
+// Iterate walks the column in place under the read lock; fn may read the
+// provider but must not modify it.
+func (v *archetypeView) Iterate(fn func(ecs.EntityID, any) bool) {
+	v.provider.mu.RLock()
+	defer v.provider.mu.RUnlock()
+	for _, table := range v.provider.tables {
+		col, ok := table.columns[v.typ]
+		if !ok {
+			continue
+		}
+		for row, id := range table.entities {
+			if !fn(id, table.data[col][row]) {
+				return
+			}
+		}
+	}
+}
+
+func (v *archetypeView) Set(id ecs.EntityID, value any) error {
+	if id.IsZero() {
+		return fmt.Errorf("archetype: cannot set zero entity")
+	}
+	v.provider.mu.Lock()
+	defer v.provider.mu.Unlock()
+	v.provider.setLocked(id, v.typ, value)
+	return nil
+}
+
+func (v *archetypeView) Remove(id ecs.EntityID) bool {
+	v.provider.mu.Lock()
+	defer v.provider.mu.Unlock()
+	return v.provider.removeLocked(id, v.typ)
+}
+
+func (v *archetypeView) Clear() {
+	v.provider.mu.Lock()
+	defer v.provider.mu.Unlock()
+	for _, table := range v.provider.tables {
+		if !table.has(v.typ) {
+			continue
+		}
+		for len(table.entities) > 0 {
+			v.provider.removeLocked(table.entities[len(table.entities)-1], v.typ)
+		}
+	}
+}
+
+var (
+	_ ecs.StorageProvider = (*ArchetypeProvider)(nil)
+	_ ecs.EntityRemover   = (*ArchetypeProvider)(nil)
+	_ ecs.ComponentLister = (*ArchetypeProvider)(nil)
+	_ ecs.ComponentStore  = (*archetypeView)(nil)
+)
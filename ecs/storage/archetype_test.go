@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"testing"
+
+	ecs "github.com/DangerosoDavo/ecs"
+)
+
+func TestArchetypeProviderMovesEntitiesBetweenTables(t *testing.T) {
+	provider := NewArchetypeProvider()
+	world := ecs.NewWorld(ecs.WithStorageProvider(provider))
+	for _, typ := range []ecs.ComponentType{"Position", "Velocity"} {
+		if err := world.RegisterComponent(typ, nil); err != nil {
+			t.Fatalf("register %s: %v", typ, err)
+		}
+	}
+
+	a := world.Registry().Create()
+	b := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(a, "Position", 1),
+		ecs.NewAddComponentCommand(b, "Position", 2),
+		ecs.NewAddComponentCommand(b, "Velocity", 20),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got := provider.TableCount(); got != 2 {
+		t.Fatalf("expected 2 tables, got %d", got)
+	}
+
+	var visited []ecs.EntityID
+	provider.Each([]ecs.ComponentType{"Velocity", "Position"}, func(id ecs.EntityID, values []any) bool {
+		visited = append(visited, id)
+		if values[0].(int) != 20 || values[1].(int) != 2 {
+			t.Fatalf("unexpected values for %v: %v", id, values)
+		}
+		return true
+	})
+	if len(visited) != 1 || visited[0] != b {
+		t.Fatalf("expected only b to match, got %v", visited)
+	}
+
+	if err := world.ApplyCommands([]ecs.Command{ecs.NewRemoveComponentCommand(b, "Velocity")}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	positions, _ := world.ViewComponent("Position")
+	velocities, _ := world.ViewComponent("Velocity")
+	if positions.Len() != 2 || velocities.Len() != 0 {
+		t.Fatalf("unexpected lengths: position=%d velocity=%d", positions.Len(), velocities.Len())
+	}
+	if got, ok := positions.Get(b); !ok || got.(int) != 2 {
+		t.Fatalf("expected b to keep position after move, got %v ok=%v", got, ok)
+	}
+	if got, ok := positions.Get(a); !ok || got.(int) != 1 {
+		t.Fatalf("expected a to keep position after swap-remove, got %v ok=%v", got, ok)
+	}
+}
+
+func TestArchetypeProviderDropsStaleGenerations(t *testing.T) {
+	provider := NewArchetypeProvider()
+	if err := provider.RegisterComponent("Tag", nil); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := provider.RegisterComponent("Health", nil); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	tags, _ := provider.View("Tag")
+	health, _ := provider.View("Health")
+
+	old := ecs.EntityIDFromParts(1, 1)
+	recycled := ecs.EntityIDFromParts(1, 2)
+	_ = tags.(ecs.ComponentStore).Set(old, "old")
+	_ = health.(ecs.ComponentStore).Set(old, 100)
+
+	if err := tags.(ecs.ComponentStore).Set(recycled, "new"); err != nil {
+		t.Fatalf("set recycled: %v", err)
+	}
+	if tags.Has(old) || health.Has(old) {
+		t.Fatalf("stale generation should no longer be stored")
+	}
+	if health.Has(recycled) {
+		t.Fatalf("recycled entity must not inherit stale components")
+	}
+	if tags.Len() != 1 || health.Len() != 0 {
+		t.Fatalf("unexpected lengths: tag=%d health=%d", tags.Len(), health.Len())
+	}
+}
+
+func TestArchetypeViewClear(t *testing.T) {
+	provider := NewArchetypeProvider()
+	_ = provider.RegisterComponent("A", nil)
+	_ = provider.RegisterComponent("B", nil)
+	a, _ := provider.View("A")
+	b, _ := provider.View("B")
+	for i := uint32(1); i <= 3; i++ {
+		id := ecs.EntityIDFromParts(i, 1)
+		_ = a.(ecs.ComponentStore).Set(id, i)
+		_ = b.(ecs.ComponentStore).Set(id, i*10)
+	}
+
+	a.(ecs.ComponentStore).Clear()
+	if a.Len() != 0 || b.Len() != 3 {
+		t.Fatalf("unexpected lengths after clear: a=%d b=%d", a.Len(), b.Len())
+	}
+	count := 0
+	b.Iterate(func(id ecs.EntityID, v any) bool {
+		count++
+		if v.(uint32) != id.Index()*10 {
+			t.Fatalf("unexpected value for %v: %v", id, v)
+		}
+		return true
+	})
+	if count != 3 {
+		t.Fatalf("expected 3 entities in B, got %d", count)
+	}
+}
+
+func TestArchetypeProviderRemovesDestroyedEntities(t *testing.T) {
+	provider := NewArchetypeProvider()
+	world := ecs.NewWorld(ecs.WithStorageProvider(provider))
+	_ = world.RegisterComponent("A", nil)
+	_ = world.RegisterComponent("B", nil)
+
+	id := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(id, "A", 1),
+		ecs.NewAddComponentCommand(id, "B", 2),
+		ecs.NewDestroyEntityCommand(id),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	for _, typ := range []ecs.ComponentType{"A", "B"} {
+		view, _ := world.ViewComponent(typ)
+		if view.Len() != 0 || view.Has(id) {
+			t.Fatalf("%s still holds destroyed entity", typ)
+		}
+	}
+}
+
+func TestArchetypeCallbacksMayReadProvider(t *testing.T) {
+	provider := NewArchetypeProvider()
+	_ = provider.RegisterComponent("A", nil)
+	_ = provider.RegisterComponent("B", nil)
+	a, _ := provider.View("A")
+	b, _ := provider.View("B")
+	for i := uint32(1); i <= 3; i++ {
+		id := ecs.EntityIDFromParts(i, 1)
+		_ = a.(ecs.ComponentStore).Set(id, i)
+		_ = b.(ecs.ComponentStore).Set(id, i*10)
+	}
+
+	// Queries join views by looking up other columns from inside a callback.
+	sum := uint32(0)
+	a.Iterate(func(id ecs.EntityID, _ any) bool {
+		v, ok := b.Get(id)
+		if !ok {
+			t.Fatalf("nested get of %v failed", id)
+		}
+		sum += v.(uint32)
+		return true
+	})
+	provider.Each([]ecs.ComponentType{"A", "B"}, func(id ecs.EntityID, values []any) bool {
+		if _, ok := a.Get(id); !ok {
+			t.Fatalf("nested read of %v failed", id)
+		}
+		sum += values[0].(uint32)
+		return true
+	})
+	if sum != 66 {
+		t.Fatalf("expected sum 66, got %d", sum)
+	}
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"fmt"
+
+	ecs "github.com/DangerosoDavo/ecs"
+)
+
+// sparsePageSize bounds how much of the sparse index is allocated per touched
+// range of entity indices.
+const sparsePageSize = 1024
+
+type sparseStrategy struct{}
+
+// NewSparseStrategy constructs a sparse-set storage strategy. Values are packed
+// into a dense array addressed through a paged sparse index, so memory and
+// iteration cost follow the number of stored components rather than the highest
+// entity index. Prefer it for tags and rarely attached components.
+func NewSparseStrategy() ecs.StorageStrategy {
+	return sparseStrategy{}
+}
+
+func (sparseStrategy) Name() string {
+	return "sparse"
+}
+
+func (sparseStrategy) NewStore(t ecs.ComponentType) ecs.ComponentStore {
+	return &sparseStore{typ: t}
+}
+
+type sparseStore struct {
+	typ ecs.ComponentType
+	// pages maps entity index to packed position plus one; zero means absent.
+	pages    [][]uint32
+	entities []ecs.EntityID
+	values   []any
+}
+
+func (s *sparseStore) ComponentType() ecs.ComponentType {
+	return s.typ
+}
+
+func (s *sparseStore) Len() int {
+	return len(s.entities)
+}
+
+func (s *sparseStore) Has(id ecs.EntityID) bool {
+	pos, ok := s.position(id.Index())
+	return ok && s.entities[pos] == id
+}
+
+func (s *sparseStore) Get(id ecs.EntityID) (any, bool) {
+	pos, ok := s.position(id.Index())
+	if !ok || s.entities[pos] != id {
+		return nil, false
+	}
+	return s.values[pos], true
+}
+
+func (s *sparseStore) Iterate(fn func(ecs.EntityID, any) bool) {
+	for i, id := range s.entities {
+		if !fn(id, s.values[i]) {
+			return
+		}
+	}
+}
+
+func (s *sparseStore) Set(id ecs.EntityID, value any) error {
+	if id.IsZero() {
+		return fmt.Errorf("sparse: cannot set zero entity")
+	}
+	if pos, ok := s.position(id.Index()); ok {
+		// Matches dense semantics: a newer generation takes over the slot.
+		s.entities[pos] = id
+		s.values[pos] = value
+		return nil
+	}
+	s.entities = append(s.entities, id)
+	s.values = append(s.values, value)
+	s.setPosition(id.Index(), uint32(len(s.entities)))
+	return nil
+}
+
+func (s *sparseStore) Remove(id ecs.EntityID) bool {
+	pos, ok := s.position(id.Index())
+	if !ok || s.entities[pos] != id {
+		return false
+	}
+	last := len(s.entities) - 1
+	if pos != last {
+		moved := s.entities[last]
+		s.entities[pos] = moved
+		s.values[pos] = s.values[last]
+		s.setPosition(moved.Index(), uint32(pos+1))
+	}
+	s.values[last] = nil
+	s.entities = s.entities[:last]
+	s.values = s.values[:last]
+	s.setPosition(id.Index(), 0)
+	return true
+}
+
+func (s *sparseStore) Clear() {
+	s.pages = nil
+	s.entities = nil
+	s.values = nil
+}
+
+func (s *sparseStore) position(index uint32) (int, bool) {
+	page := int(index / sparsePageSize)
+	if page >= len(s.pages) || s.pages[page] == nil {
+		return 0, false
+	}
+	slot := s.pages[page][index%sparsePageSize]
+	if slot == 0 {
+		return 0, false
+	}
+	return int(slot - 1), true
+}
+
+func (s *sparseStore) setPosition(index, slot uint32) {
+	page := int(index / sparsePageSize)
+	if page >= len(s.pages) {
+		if slot == 0 {
+			return
+		}
+		s.pages = append(s.pages, make([][]uint32, page+1-len(s.pages))...)
+	}
+	if s.pages[page] == nil {
+		if slot == 0 {
+			return
+		}
+		s.pages[page] = make([]uint32, sparsePageSize)
+	}
+	s.pages[page][index%sparsePageSize] = slot
+}
+
+var _ ecs.ComponentStore = (*sparseStore)(nil)
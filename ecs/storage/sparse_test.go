@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"testing"
+
+	ecs "github.com/DangerosoDavo/ecs"
+)
+
+func TestSparseStoreCRUD(t *testing.T) {
+	store := NewSparseStrategy().NewStore(ecs.ComponentType("tag")).(*sparseStore)
+
+	a := ecs.EntityIDFromParts(3, 1)
+	b := ecs.EntityIDFromParts(900_000, 1)
+	c := ecs.EntityIDFromParts(7, 2)
+	for i, id := range []ecs.EntityID{a, b, c} {
+		if err := store.Set(id, i); err != nil {
+			t.Fatalf("set %v: %v", id, err)
+		}
+	}
+	if store.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %d", store.Len())
+	}
+	allocated := 0
+	for _, page := range store.pages {
+		if page != nil {
+			allocated++
+		}
+	}
+	if allocated != 2 {
+		t.Fatalf("expected 2 allocated pages, got %d", allocated)
+	}
+
+	if !store.Remove(a) {
+		t.Fatalf("remove failed")
+	}
+	if store.Has(a) {
+		t.Fatalf("removed entity still present")
+	}
+	if got, ok := store.Get(c); !ok || got.(int) != 2 {
+		t.Fatalf("swap-remove corrupted moved entry: %v ok=%v", got, ok)
+	}
+
+	visited := 0
+	store.Iterate(func(ecs.EntityID, any) bool {
+		visited++
+		return true
+	})
+	if visited != store.Len() {
+		t.Fatalf("iterate visited %d entries, want %d", visited, store.Len())
+	}
+}
+
+func TestSparseStoreGenerationChecks(t *testing.T) {
+	store := NewSparseStrategy().NewStore(ecs.ComponentType("tag"))
+	old := ecs.EntityIDFromParts(5, 1)
+	recycled := ecs.EntityIDFromParts(5, 2)
+
+	_ = store.Set(old, "old")
+	if store.Has(recycled) {
+		t.Fatalf("newer generation must not match stale entry")
+	}
+	if store.Remove(recycled) {
+		t.Fatalf("remove with mismatched generation should fail")
+	}
+
+	_ = store.Set(recycled, "new")
+	if store.Has(old) {
+		t.Fatalf("stale generation should be replaced")
+	}
+	if store.Len() != 1 {
+		t.Fatalf("expected slot reuse, got len %d", store.Len())
+	}
+}
+
+func TestSparseStoreRejectsZeroEntity(t *testing.T) {
+	store := NewSparseStrategy().NewStore(ecs.ComponentType("tag"))
+	if err := store.Set(ecs.EntityID{}, 1); err == nil {
+		t.Fatalf("expected error for zero entity")
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	ecs "github.com/DangerosoDavo/ecs"
+)
+
+// benchStrategies lists the per-component strategies compared by the benchmarks.
+var benchStrategies = []struct {
+	name     string
+	strategy ecs.StorageStrategy
+}{
+	{name: "dense", strategy: NewDenseStrategy()},
+	{name: "shared", strategy: NewSharedStrategy()},
+	{name: "sparse", strategy: NewSparseStrategy()},
+}
+
+// BenchmarkStoreSetRemove measures add/remove churn on 1,024 contiguous
+// entities. Dense and sparse should both be O(1); shared pays for
+// deduplication scans and map churn.
+func BenchmarkStoreSetRemove(b *testing.B) {
+	const entities = 1024
+	for _, bs := range benchStrategies {
+		b.Run(bs.name, func(b *testing.B) {
+			store := bs.strategy.NewStore("bench")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				id := ecs.EntityIDFromParts(uint32(i%entities)+1, 1)
+				_ = store.Set(id, i%8)
+				store.Remove(id)
+			}
+		})
+	}
+}
+
+// BenchmarkStoreIterateSparseHighIndex stores a small number of components on
+// entities with indices spread up to ~1M, the tag-like workload. Dense
+// iteration scans every slot up to the highest index while sparse visits only
+// the populated entries.
+func BenchmarkStoreIterateSparseHighIndex(b *testing.B) {
+	for _, count := range []int{16, 1024} {
+		for _, bs := range benchStrategies {
+			b.Run(fmt.Sprintf("%s/n=%d", bs.name, count), func(b *testing.B) {
+				store := bs.strategy.NewStore("bench")
+				stride := uint32(1_000_000 / count)
+				for i := 0; i < count; i++ {
+					_ = store.Set(ecs.EntityIDFromParts(uint32(i+1)*stride, 1), i)
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					visited := 0
+					store.Iterate(func(ecs.EntityID, any) bool {
+						visited++
+						return true
+					})
+					if visited != count {
+						b.Fatalf("visited %d, want %d", visited, count)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkStoreGet measures random-access lookups on 4,096 populated entities.
+func BenchmarkStoreGet(b *testing.B) {
+	const entities = 4096
+	for _, bs := range benchStrategies {
+		b.Run(bs.name, func(b *testing.B) {
+			store := bs.strategy.NewStore("bench")
+			for i := 0; i < entities; i++ {
+				_ = store.Set(ecs.EntityIDFromParts(uint32(i+1), 1), i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, ok := store.Get(ecs.EntityIDFromParts(uint32(i%entities)+1, 1)); !ok {
+					b.Fatalf("missing entity")
+				}
+			}
+		})
+	}
+}
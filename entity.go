@@ -54,13 +54,22 @@ type EntityRegistry struct {
 
 // Create issues a new entity identifier, recycling slots when possible.
 func (r *EntityRegistry) Create() EntityID {
+	id, _ := r.create()
+	return id
+}
+
+// create is Create that also reports whether the slot came off the free list,
+// which rollbackCreate needs to undo it.
+func (r *EntityRegistry) create() (EntityID, bool) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	var index uint32
+	recycled := false
 	if n := len(r.free); n > 0 {
 		index = r.free[n-1]
 		r.free = r.free[:n-1]
+		recycled = true
 	} else {
 		index = uint32(len(r.generations))
 		r.generations = append(r.generations, 0)
@@ -69,7 +78,7 @@ func (r *EntityRegistry) Create() EntityID {
 	r.generations[index]++
 	generation := r.generations[index]
 	r.alive++
-	return EntityID{index: index, generation: generation}
+	return EntityID{index: index, generation: generation}, recycled
 }
 
 // Destroy releases the entity identifier, returning true when successful.
@@ -91,6 +100,31 @@ func (r *EntityRegistry) Destroy(id EntityID) bool {
 	return true
 }
 
+// rollbackCreate undoes the most recent create of id. Fresh slots are always
+// the last slot; recycled slots return to the end of the free list they were
+// popped from.
+func (r *EntityRegistry) rollbackCreate(id EntityID, recycled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alive--
+	if !recycled {
+		r.generations = r.generations[:id.index]
+		return
+	}
+	r.generations[id.index]--
+	r.free = append(r.free, id.index)
+}
+
+// rollbackDestroy undoes the most recent Destroy, which pushed id's slot onto
+// the free list.
+func (r *EntityRegistry) rollbackDestroy(id EntityID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alive++
+	r.generations[id.index] = id.generation
+	r.free = r.free[:len(r.free)-1]
+}
+
 // IsAlive reports whether the identifier refers to a currently allocated entity.
 func (r *EntityRegistry) IsAlive(id EntityID) bool {
 	if id.IsZero() {
@@ -116,3 +150,20 @@ func (r *EntityRegistry) isAliveLocked(id EntityID) bool {
 	}
 	return r.generations[idx] == id.generation
 }
+
+// exportState copies the allocation state so it can be serialized.
+func (r *EntityRegistry) exportState() (generations, free []uint32, alive uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]uint32(nil), r.generations...), append([]uint32(nil), r.free...), r.alive
+}
+
+// importState replaces the allocation state, preserving generations and the
+// free list so previously issued handles keep their meaning.
+func (r *EntityRegistry) importState(generations, free []uint32, alive uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generations = append([]uint32(nil), generations...)
+	r.free = append([]uint32(nil), free...)
+	r.alive = alive
+}
@@ -7,10 +7,14 @@ var (
 	ErrComponentAlreadyRegistered = errors.New("ecs: component already registered")
 	// ErrComponentNotRegistered signals lookup on an unknown component type.
 	ErrComponentNotRegistered = errors.New("ecs: component not registered")
+	// ErrComponentTypeMismatch indicates a component value or handle disagrees with the registered Go type.
+	ErrComponentTypeMismatch = errors.New("ecs: component Go type mismatch")
 	// ErrNilStorageStrategy is returned when storage registration receives a nil strategy.
 	ErrNilStorageStrategy = errors.New("ecs: nil storage strategy")
 	// ErrNilComponentStore is returned when a strategy produces a nil store.
 	ErrNilComponentStore = errors.New("ecs: strategy returned nil store")
+	// ErrSchedulerClosed indicates the scheduler was shut down.
+	ErrSchedulerClosed = errors.New("ecs: scheduler closed")
 	// ErrWorkerPoolClosed indicates jobs cannot be submitted because the pool closed.
 	ErrWorkerPoolClosed = errors.New("ecs: worker pool closed")
 	// ErrAsyncWritesNotSupported indicates an async work group attempted to mutate components.
@@ -19,8 +23,46 @@ var (
 	ErrAsyncSystemNotAllowed = errors.New("ecs: system does not allow async execution")
 	// ErrDuplicateWriteAccess indicates conflicting write access within a work group.
 	ErrDuplicateWriteAccess = errors.New("ecs: duplicate write access to component in work group")
+	// ErrHookFollowUpLimit indicates component hooks kept queuing follow-up commands.
+	ErrHookFollowUpLimit = errors.New("ecs: component hook follow-up limit exceeded")
+	// ErrOrderingCycle indicates Before/After constraints that cannot be satisfied.
+	ErrOrderingCycle = errors.New("ecs: ordering constraints form a cycle")
+	// ErrAsyncOrdering indicates Before/After constraints that link an async work group.
+	ErrAsyncOrdering = errors.New("ecs: ordering constraints cannot involve async work groups")
 	// ErrDuplicateResourceWriteAccess indicates conflicting resource write claims.
 	ErrDuplicateResourceWriteAccess = errors.New("ecs: duplicate write access to resource in work group")
 	// ErrAsyncResourceWritesNotSupported indicates async groups attempted to mutate resources.
 	ErrAsyncResourceWritesNotSupported = errors.New("ecs: async work group cannot perform resource writes")
+	// ErrDuplicateEventWriteAccess indicates conflicting event writer claims.
+	ErrDuplicateEventWriteAccess = errors.New("ecs: duplicate write access to event channel")
+	// ErrAsyncEventWritesNotSupported indicates async groups attempted to emit events.
+	ErrAsyncEventWritesNotSupported = errors.New("ecs: async work group cannot emit events")
+	// ErrEventNotRegistered signals use of an unknown event channel.
+	ErrEventNotRegistered = errors.New("ecs: event channel not registered")
+	// ErrEventTypeMismatch indicates an event handle disagrees with the channel's Go type.
+	ErrEventTypeMismatch = errors.New("ecs: event Go type mismatch")
+	// ErrSystemPanic matches every SystemPanicError.
+	ErrSystemPanic = errors.New("ecs: system panicked")
+	// ErrCodecNotRegistered indicates a snapshot needs a codec that was never registered.
+	ErrCodecNotRegistered = errors.New("ecs: codec not registered")
+	// ErrSnapshotInvalid indicates snapshot input is truncated, corrupt, or not a snapshot.
+	ErrSnapshotInvalid = errors.New("ecs: invalid snapshot")
+	// ErrSnapshotVersion indicates a snapshot or codec version the reader cannot decode.
+	ErrSnapshotVersion = errors.New("ecs: unsupported snapshot version")
+	// ErrSnapshotManifest indicates the snapshot manifest does not match the world's registrations.
+	ErrSnapshotManifest = errors.New("ecs: snapshot manifest mismatch")
+	// ErrQueryRequiresComponent indicates a query was declared without required components.
+	ErrQueryRequiresComponent = errors.New("ecs: query requires at least one With component")
+	// ErrQueryConflictingFilter indicates a component appears in incompatible query filters.
+	ErrQueryConflictingFilter = errors.New("ecs: conflicting query filter")
+	// ErrQueryAccessUndeclared indicates a query touches components missing from a system descriptor.
+	ErrQueryAccessUndeclared = errors.New("ecs: query access not declared by system")
+	// ErrCommandsRolledBack indicates a transactional ApplyCommands undid its batch after a failure.
+	ErrCommandsRolledBack = errors.New("ecs: command batch rolled back")
+	// ErrReplayInvalid indicates replay input is truncated, corrupt, or not a recording.
+	ErrReplayInvalid = errors.New("ecs: invalid replay")
+	// ErrReplayDiverged matches every ReplayDivergence.
+	ErrReplayDiverged = errors.New("ecs: replay diverged")
+	// ErrHierarchyCycle indicates a parent link that would make an entity its own ancestor.
+	ErrHierarchyCycle = errors.New("ecs: entity hierarchy cycle")
 )
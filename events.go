@@ -0,0 +1,227 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// EventChannel is a typed handle for a named event stream registered on a
+// world. Events emitted during a tick stay readable through the end of the
+// following tick, after which the scheduler drops them.
+type EventChannel[T any] struct {
+	name string
+}
+
+// RegisterEvent creates the named event channel carrying T. Registering the
+// same name again with the same type returns the existing channel.
+func RegisterEvent[T any](world *World, name string) (EventChannel[T], error) {
+	if _, err := world.events.register(name, typeOf[T](), func() eventQueue { return &typedEventQueue[T]{} }); err != nil {
+		return EventChannel[T]{}, err
+	}
+	return EventChannel[T]{name: name}, nil
+}
+
+// LookupEvent returns a handle for a channel registered elsewhere.
+func LookupEvent[T any](world *World, name string) (EventChannel[T], error) {
+	if _, err := eventQueueFor[T](world, name); err != nil {
+		return EventChannel[T]{}, err
+	}
+	return EventChannel[T]{name: name}, nil
+}
+
+// Name returns the channel name used in EventAccess declarations.
+func (c EventChannel[T]) Name() string {
+	return c.name
+}
+
+// Emit publishes an event once the emitting system completes successfully, so
+// readers in later groups of the same tick observe it while a failed or
+// retried run leaves nothing behind. Outside a scheduler run it publishes
+// immediately. Emitting systems should declare AccessModeWrite.
+func (c EventChannel[T]) Emit(exec ExecutionContext, event T) error {
+	queue, err := eventQueueFor[T](exec.World(), c.name)
+	if err != nil {
+		return err
+	}
+	if stager, ok := exec.(eventStager); ok {
+		stager.stageEvent(func() { queue.push(event) })
+		return nil
+	}
+	queue.push(event)
+	return nil
+}
+
+// eventStager is implemented by execution contexts that hold emits until the
+// running system completes.
+type eventStager interface {
+	stageEvent(publish func())
+}
+
+// EmitCommand returns a command that publishes event when applied, for code
+// that wants the event to follow a command batch.
+func (c EventChannel[T]) EmitCommand(event T) Command {
+	return emitEventCommand[T]{name: c.name, event: event}
+}
+
+type emitEventCommand[T any] struct {
+	name  string
+	event T
+}
+
+func (c emitEventCommand[T]) Apply(world *World) error {
+	queue, err := eventQueueFor[T](world, c.name)
+	if err != nil {
+		return err
+	}
+	queue.push(c.event)
+	world.record(queue.pop)
+	return nil
+}
+
+// Reader returns a cursor that yields each event on the channel at most once.
+// Every reading system should own its reader.
+func (c EventChannel[T]) Reader() *EventReader[T] {
+	return &EventReader[T]{name: c.name}
+}
+
+// EventReader tracks how far a single consumer has read a channel.
+type EventReader[T any] struct {
+	name   string
+	mu     sync.Mutex
+	cursor uint64
+}
+
+// Read visits unread events in emission order, stopping early if fn returns
+// false. Events dropped before the reader ran are skipped silently.
+func (r *EventReader[T]) Read(world *World, fn func(event T) bool) error {
+	queue, err := eventQueueFor[T](world, r.name)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, record := range queue.since(r.cursor) {
+		r.cursor = record.seq + 1
+		if !fn(record.value) {
+			break
+		}
+	}
+	return nil
+}
+
+func eventQueueFor[T any](world *World, name string) (*typedEventQueue[T], error) {
+	queue, err := world.events.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	typed, ok := queue.(*typedEventQueue[T])
+	if !ok {
+		return nil, fmt.Errorf("%w: %s carries %s, not %s", ErrEventTypeMismatch, name, queue.goType(), typeOf[T]())
+	}
+	return typed, nil
+}
+
+// eventBus owns a world's event channels.
+type eventBus struct {
+	mu       sync.RWMutex
+	channels map[string]eventQueue
+}
+
+type eventQueue interface {
+	goType() reflect.Type
+	swap()
+}
+
+func (b *eventBus) register(name string, goType reflect.Type, create func() eventQueue) (eventQueue, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if existing, ok := b.channels[name]; ok {
+		if existing.goType() != goType {
+			return nil, fmt.Errorf("%w: %s carries %s, not %s", ErrEventTypeMismatch, name, existing.goType(), goType)
+		}
+		return existing, nil
+	}
+	if b.channels == nil {
+		b.channels = make(map[string]eventQueue)
+	}
+	queue := create()
+	b.channels[name] = queue
+	return queue, nil
+}
+
+func (b *eventBus) lookup(name string) (eventQueue, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	queue, ok := b.channels[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotRegistered, name)
+	}
+	return queue, nil
+}
+
+// advance rotates every channel's buffers at a tick boundary.
+func (b *eventBus) advance() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, queue := range b.channels {
+		queue.swap()
+	}
+}
+
+// typedEventQueue double-buffers events: current collects this tick's events,
+// previous keeps last tick's for readers that run earlier in the order.
+type typedEventQueue[T any] struct {
+	mu       sync.Mutex
+	next     uint64
+	previous []eventRecord[T]
+	current  []eventRecord[T]
+}
+
+type eventRecord[T any] struct {
+	seq   uint64
+	value T
+}
+
+func (q *typedEventQueue[T]) goType() reflect.Type {
+	return typeOf[T]()
+}
+
+func (q *typedEventQueue[T]) push(value T) {
+	q.mu.Lock()
+	q.current = append(q.current, eventRecord[T]{seq: q.next, value: value})
+	q.next++
+	q.mu.Unlock()
+}
+
+// pop drops the newest event, undoing a push rolled back by a transaction.
+func (q *typedEventQueue[T]) pop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n := len(q.current); n > 0 {
+		q.current = q.current[:n-1]
+		q.next--
+	}
+}
+
+// since copies buffered events with a sequence number at or after cursor.
+func (q *typedEventQueue[T]) since(cursor uint64) []eventRecord[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]eventRecord[T], 0, len(q.previous)+len(q.current))
+	for _, buf := range [][]eventRecord[T]{q.previous, q.current} {
+		for _, record := range buf {
+			if record.seq >= cursor {
+				out = append(out, record)
+			}
+		}
+	}
+	return out
+}
+
+func (q *typedEventQueue[T]) swap() {
+	q.mu.Lock()
+	clear(q.previous)
+	q.previous, q.current = q.current, q.previous[:0]
+	q.mu.Unlock()
+}
@@ -0,0 +1,213 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+type damageDealt struct {
+	Target ecs.EntityID
+	Amount int
+}
+
+type eventReaderSystem struct {
+	name   string
+	reader *ecs.EventReader[damageDealt]
+	seen   [][]int
+}
+
+func (s *eventReaderSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{
+		Name:   s.name,
+		Events: []ecs.EventAccess{{Name: "damage", Mode: ecs.AccessModeRead}},
+	}
+}
+
+func (s *eventReaderSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	var amounts []int
+	err := s.reader.Read(exec.World(), func(ev damageDealt) bool {
+		amounts = append(amounts, ev.Amount)
+		return true
+	})
+	s.seen = append(s.seen, amounts)
+	return ecs.SystemResult{Err: err}
+}
+
+func TestEventsAreDoubleBufferedAcrossTicks(t *testing.T) {
+	world := ecs.NewWorld()
+	damage, err := ecs.RegisterEvent[damageDealt](world, "damage")
+	if err != nil {
+		t.Fatalf("register event: %v", err)
+	}
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	emitted := 0
+	writer := &testSystem{
+		name: "combat",
+		desc: ecs.SystemDescriptor{Events: []ecs.EventAccess{{Name: "damage", Mode: ecs.AccessModeWrite}}},
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			if ctx.TickIndex() == 0 {
+				emitted++
+				_ = damage.Emit(ctx, damageDealt{Amount: 5})
+			}
+		},
+	}
+	early := &eventReaderSystem{name: "early", reader: damage.Reader()}
+	late := &eventReaderSystem{name: "late", reader: damage.Reader()}
+
+	groups := []ecs.WorkGroupConfig{
+		{ID: "early", Systems: []ecs.System{early}},
+		{ID: "combat", Systems: []ecs.System{writer}},
+		{ID: "late", Systems: []ecs.System{late}},
+	}
+	for _, cfg := range groups {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+	if err := scheduler.Run(context.Background(), 3, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	// The late reader sees the event in the tick it was emitted, the early
+	// reader one tick later; neither sees it twice.
+	if len(late.seen[0]) != 1 || len(late.seen[1]) != 0 || len(late.seen[2]) != 0 {
+		t.Fatalf("unexpected late reads: %v", late.seen)
+	}
+	if len(early.seen[0]) != 0 || len(early.seen[1]) != 1 || len(early.seen[2]) != 0 {
+		t.Fatalf("unexpected early reads: %v", early.seen)
+	}
+
+	// After two tick boundaries the event is gone even for a fresh reader.
+	fresh := damage.Reader()
+	count := 0
+	_ = fresh.Read(world, func(damageDealt) bool {
+		count++
+		return true
+	})
+	if emitted != 1 || count != 0 {
+		t.Fatalf("expected event to expire, emitted=%d fresh=%d", emitted, count)
+	}
+}
+
+func TestSchedulerValidatesEventAccess(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	scheduler.Builder().WithAsyncWorkers(1)
+
+	writes := ecs.SystemDescriptor{Events: []ecs.EventAccess{{Name: "damage", Mode: ecs.AccessModeWrite}}}
+	reads := ecs.SystemDescriptor{Events: []ecs.EventAccess{{Name: "damage", Mode: ecs.AccessModeRead}}, AsyncAllowed: true}
+
+	asyncWriter := writes
+	asyncWriter.AsyncAllowed = true
+	_, err = scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "async", Mode: ecs.WorkGroupModeAsync, Systems: []ecs.System{&testSystem{name: "w", desc: asyncWriter}}})
+	if !errors.Is(err, ecs.ErrAsyncEventWritesNotSupported) {
+		t.Fatalf("expected ErrAsyncEventWritesNotSupported, got %v", err)
+	}
+
+	_, err = scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "pair", Systems: []ecs.System{
+		&testSystem{name: "a", desc: writes},
+		&testSystem{name: "b", desc: writes},
+	}})
+	if !errors.Is(err, ecs.ErrDuplicateEventWriteAccess) {
+		t.Fatalf("expected ErrDuplicateEventWriteAccess, got %v", err)
+	}
+
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "combat", Systems: []ecs.System{&testSystem{name: "combat", desc: writes}}}); err != nil {
+		t.Fatalf("register writer: %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "ui", Mode: ecs.WorkGroupModeAsync, Systems: []ecs.System{&testSystem{name: "ui", desc: reads}}}); err != nil {
+		t.Fatalf("readers in other groups should be allowed: %v", err)
+	}
+	_, err = scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "other", Systems: []ecs.System{&testSystem{name: "other", desc: writes}}})
+	if !errors.Is(err, ecs.ErrDuplicateEventWriteAccess) {
+		t.Fatalf("expected cross-group ErrDuplicateEventWriteAccess, got %v", err)
+	}
+}
+
+func TestEventChannelTypeMismatch(t *testing.T) {
+	world := ecs.NewWorld()
+	if _, err := ecs.RegisterEvent[damageDealt](world, "damage"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if _, err := ecs.RegisterEvent[string](world, "damage"); !errors.Is(err, ecs.ErrEventTypeMismatch) {
+		t.Fatalf("expected ErrEventTypeMismatch, got %v", err)
+	}
+	if _, err := ecs.LookupEvent[int](world, "damage"); !errors.Is(err, ecs.ErrEventTypeMismatch) {
+		t.Fatalf("expected ErrEventTypeMismatch on lookup, got %v", err)
+	}
+	if _, err := ecs.LookupEvent[int](world, "missing"); !errors.Is(err, ecs.ErrEventNotRegistered) {
+		t.Fatalf("expected ErrEventNotRegistered, got %v", err)
+	}
+}
+
+func TestEventsFromFailedRunsAreDropped(t *testing.T) {
+	world := ecs.NewWorld(ecs.WithApplyMode(ecs.ApplyTransactional))
+	damage, err := ecs.RegisterEvent[damageDealt](world, "damage")
+	if err != nil {
+		t.Fatalf("register event: %v", err)
+	}
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	stale := world.Registry().Create()
+	world.Registry().Destroy(stale)
+
+	// The first run fails and is retried; the third defers an emit alongside
+	// a command that fails, rolling back the whole batch.
+	runs := 0
+	combat := &testSystem{
+		name: "combat",
+		desc: ecs.SystemDescriptor{
+			Events: []ecs.EventAccess{{Name: "damage", Mode: ecs.AccessModeWrite}},
+			Retry:  &ecs.RetryPolicy{MaxAttempts: 2},
+		},
+		failLimit: 1,
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			runs++
+			switch runs {
+			case 1, 2:
+				_ = damage.Emit(ctx, damageDealt{Amount: runs})
+			case 3:
+				ctx.Defer(damage.EmitCommand(damageDealt{Amount: 3}))
+				ctx.Defer(ecs.NewDestroyEntityCommand(stale))
+			}
+		},
+	}
+	reader := &eventReaderSystem{name: "reader", reader: damage.Reader()}
+	for _, cfg := range []ecs.WorkGroupConfig{
+		{ID: "combat", Systems: []ecs.System{combat}},
+		{ID: "reader", Systems: []ecs.System{reader}},
+	} {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+
+	ctx := context.Background()
+	if err := scheduler.Tick(ctx, time.Millisecond); err != nil {
+		t.Fatalf("first tick: %v", err)
+	}
+	if err := scheduler.Tick(ctx, time.Millisecond); !errors.Is(err, ecs.ErrCommandsRolledBack) {
+		t.Fatalf("expected second tick to roll back, got %v", err)
+	}
+	if err := scheduler.Tick(ctx, time.Millisecond); err != nil {
+		t.Fatalf("third tick: %v", err)
+	}
+
+	// Only the successful retry's emit lands, in the same tick, and the
+	// rolled-back emit never lands.
+	if len(reader.seen) != 3 || len(reader.seen[0]) != 1 || reader.seen[0][0] != 2 || len(reader.seen[1]) != 0 || len(reader.seen[2]) != 0 {
+		t.Fatalf("unexpected reads: %v", reader.seen)
+	}
+}
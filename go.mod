@@ -1,3 +1,5 @@
 module github.com/DangerosoDavo/ecs
 
-go 1.25
+go 1.25.0
+
+require golang.org/x/exp v0.0.0-20260611194520-c48552f49976
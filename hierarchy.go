@@ -0,0 +1,305 @@
+package ecs
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// NewSetParentCommand enqueues attaching child under parent, replacing any
+// previous parent. Both entities must be alive and the link must not form a
+// cycle.
+func NewSetParentCommand(child, parent EntityID) Command {
+	return setParentCommand{child: child, parent: parent}
+}
+
+// NewRemoveParentCommand enqueues detaching child from its parent, making it a
+// root. Entities without a parent are left unchanged.
+func NewRemoveParentCommand(child EntityID) Command {
+	return removeParentCommand{child: child}
+}
+
+// NewDestroyRecursiveCommand enqueues destroying root and all of its
+// descendants, children before their parents. NewDestroyEntityCommand
+// cascades the same way; this name makes the intent explicit.
+func NewDestroyRecursiveCommand(root EntityID) Command {
+	return destroyRecursiveCommand{root: root}
+}
+
+type setParentCommand struct {
+	child  EntityID
+	parent EntityID
+}
+
+type removeParentCommand struct {
+	child EntityID
+}
+
+type destroyRecursiveCommand struct {
+	root EntityID
+}
+
+func (c setParentCommand) Apply(world *World) error {
+	if c.child.IsZero() || c.parent.IsZero() {
+		return fmt.Errorf("ecs: set parent with zero entity")
+	}
+	child, err := world.resolve(c.child)
+	if err != nil {
+		return err
+	}
+	parent, err := world.resolve(c.parent)
+	if err != nil {
+		return err
+	}
+	if !world.registry.IsAlive(child) {
+		return fmt.Errorf("ecs: set parent of stale entity %v", child)
+	}
+	if !world.registry.IsAlive(parent) {
+		return fmt.Errorf("ecs: set parent to stale entity %v", parent)
+	}
+	if world.journaling() {
+		previous, _ := world.hierarchy.parent(child)
+		world.journalHierarchy(child, previous, parent)
+	}
+	return world.hierarchy.attach(child, parent)
+}
+
+func (c removeParentCommand) Apply(world *World) error {
+	if c.child.IsZero() {
+		return fmt.Errorf("ecs: remove parent of zero entity")
+	}
+	child, err := world.resolve(c.child)
+	if err != nil {
+		return err
+	}
+	if !world.registry.IsAlive(child) {
+		return fmt.Errorf("ecs: remove parent of stale entity %v", child)
+	}
+	if world.journaling() {
+		previous, _ := world.hierarchy.parent(child)
+		world.journalHierarchy(child, previous)
+	}
+	world.hierarchy.detachParent(child)
+	return nil
+}
+
+func (c destroyRecursiveCommand) Apply(world *World) error {
+	if c.root.IsZero() {
+		return fmt.Errorf("ecs: destroy zero entity")
+	}
+	root, err := world.resolve(c.root)
+	if err != nil {
+		return err
+	}
+	return world.destroySubtree(root)
+}
+
+// destroySubtree destroys root and its descendants, children before their
+// parents, so no child outlives its parent.
+func (w *World) destroySubtree(root EntityID) error {
+	if !w.registry.IsAlive(root) {
+		return fmt.Errorf("ecs: destroy stale entity %v", root)
+	}
+	// Hook follow-ups apply after the batch, so the subtree cannot change
+	// while it is torn down.
+	for _, id := range w.hierarchy.subtree(root) {
+		if err := w.destroyEntity(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	_ Command = setParentCommand{}
+	_ Command = removeParentCommand{}
+	_ Command = destroyRecursiveCommand{}
+)
+
+// Parent returns the entity's parent, if it has one.
+func (w *World) Parent(id EntityID) (EntityID, bool) {
+	return w.hierarchy.parent(id)
+}
+
+// Children returns a copy of the entity's children in attachment order.
+func (w *World) Children(id EntityID) []EntityID {
+	return w.hierarchy.childrenOf(id)
+}
+
+// Ancestors returns the entity's parent chain, nearest first.
+func (w *World) Ancestors(id EntityID) []EntityID {
+	return w.hierarchy.ancestors(id)
+}
+
+// entityHierarchy stores parent links keyed by full entity IDs, so a recycled
+// index never inherits the relationships of the entity it replaced. Links are
+// dropped when either side is destroyed.
+type entityHierarchy struct {
+	mu       sync.RWMutex
+	parents  map[EntityID]EntityID
+	children map[EntityID][]EntityID
+}
+
+func (h *entityHierarchy) attach(child, parent EntityID) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for at := parent; !at.IsZero(); at = h.parents[at] {
+		if at == child {
+			return fmt.Errorf("%w: %v cannot become a child of %v", ErrHierarchyCycle, child, parent)
+		}
+	}
+	if current, ok := h.parents[child]; ok {
+		if current == parent {
+			return nil
+		}
+		h.unlinkLocked(child, current)
+	}
+	if h.parents == nil {
+		h.parents = make(map[EntityID]EntityID)
+		h.children = make(map[EntityID][]EntityID)
+	}
+	h.parents[child] = parent
+	h.children[parent] = append(h.children[parent], child)
+	return nil
+}
+
+func (h *entityHierarchy) detachParent(child EntityID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if parent, ok := h.parents[child]; ok {
+		h.unlinkLocked(child, parent)
+	}
+}
+
+// forget drops every link of a destroyed entity; its children become roots.
+func (h *entityHierarchy) forget(id EntityID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if parent, ok := h.parents[id]; ok {
+		h.unlinkLocked(id, parent)
+	}
+	for _, child := range h.children[id] {
+		delete(h.parents, child)
+	}
+	delete(h.children, id)
+}
+
+func (h *entityHierarchy) unlinkLocked(child, parent EntityID) {
+	delete(h.parents, child)
+	siblings := slices.DeleteFunc(h.children[parent], func(id EntityID) bool { return id == child })
+	if len(siblings) == 0 {
+		delete(h.children, parent)
+		return
+	}
+	h.children[parent] = siblings
+}
+
+func (h *entityHierarchy) parent(id EntityID) (EntityID, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	parent, ok := h.parents[id]
+	return parent, ok
+}
+
+func (h *entityHierarchy) childrenOf(id EntityID) []EntityID {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return slices.Clone(h.children[id])
+}
+
+func (h *entityHierarchy) ancestors(id EntityID) []EntityID {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var out []EntityID
+	for parent, ok := h.parents[id]; ok; parent, ok = h.parents[parent] {
+		out = append(out, parent)
+	}
+	return out
+}
+
+// subtree lists root and its descendants in post-order, so every entity
+// appears after its children.
+func (h *entityHierarchy) subtree(root EntityID) []EntityID {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var out []EntityID
+	var walk func(id EntityID)
+	walk = func(id EntityID) {
+		for _, child := range h.children[id] {
+			walk(child)
+		}
+		out = append(out, id)
+	}
+	walk(root)
+	return out
+}
+
+// export returns parents ordered by entity index with their children in
+// attachment order, so snapshots of identical worlds are byte-identical.
+func (h *entityHierarchy) export() []hierarchyLinks {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make([]hierarchyLinks, 0, len(h.children))
+	for parent, children := range h.children {
+		out = append(out, hierarchyLinks{parent: parent, children: slices.Clone(children)})
+	}
+	slices.SortFunc(out, func(a, b hierarchyLinks) int { return cmp.Compare(a.parent.index, b.parent.index) })
+	return out
+}
+
+// replace swaps in links decoded by Restore, which has already validated them.
+func (h *entityHierarchy) replace(links []hierarchyLinks) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.parents = make(map[EntityID]EntityID)
+	h.children = make(map[EntityID][]EntityID, len(links))
+	for _, link := range links {
+		h.children[link.parent] = link.children
+		for _, child := range link.children {
+			h.parents[child] = link.parent
+		}
+	}
+}
+
+// save captures the links held by ids and returns a func restoring them.
+func (h *entityHierarchy) save(ids ...EntityID) func() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	type saved struct {
+		id        EntityID
+		parent    EntityID
+		hasParent bool
+		children  []EntityID
+	}
+	states := make([]saved, 0, len(ids))
+	for _, id := range ids {
+		parent, ok := h.parents[id]
+		states = append(states, saved{id: id, parent: parent, hasParent: ok, children: slices.Clone(h.children[id])})
+	}
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.parents == nil {
+			h.parents = make(map[EntityID]EntityID)
+			h.children = make(map[EntityID][]EntityID)
+		}
+		for _, state := range states {
+			if state.hasParent {
+				h.parents[state.id] = state.parent
+			} else {
+				delete(h.parents, state.id)
+			}
+			if len(state.children) > 0 {
+				h.children[state.id] = state.children
+			} else {
+				delete(h.children, state.id)
+			}
+		}
+	}
+}
+
+type hierarchyLinks struct {
+	parent   EntityID
+	children []EntityID
+}
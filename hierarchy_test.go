@@ -0,0 +1,167 @@
+package ecs_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+func TestHierarchyQueries(t *testing.T) {
+	world := ecs.NewWorld()
+	squad := world.Registry().Create()
+	player := world.Registry().Create()
+	weapon := world.Registry().Create()
+	medkit := world.Registry().Create()
+	if err := world.ApplyCommands([]ecs.Command{
+		ecs.NewSetParentCommand(player, squad),
+		ecs.NewSetParentCommand(weapon, player),
+		ecs.NewSetParentCommand(medkit, player),
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if parent, ok := world.Parent(weapon); !ok || parent != player {
+		t.Fatalf("expected weapon parent %v, got %v (%v)", player, parent, ok)
+	}
+	if _, ok := world.Parent(squad); ok {
+		t.Fatalf("expected squad to be a root")
+	}
+	if got := world.Children(player); !slices.Equal(got, []ecs.EntityID{weapon, medkit}) {
+		t.Fatalf("unexpected children %v", got)
+	}
+	if got := world.Ancestors(weapon); !slices.Equal(got, []ecs.EntityID{player, squad}) {
+		t.Fatalf("unexpected ancestors %v", got)
+	}
+
+	if err := ecs.NewSetParentCommand(squad, weapon).Apply(world); !errors.Is(err, ecs.ErrHierarchyCycle) {
+		t.Fatalf("expected ErrHierarchyCycle, got %v", err)
+	}
+	if err := ecs.NewSetParentCommand(weapon, squad).Apply(world); err != nil {
+		t.Fatalf("reparent: %v", err)
+	}
+	if got := world.Children(player); !slices.Equal(got, []ecs.EntityID{medkit}) {
+		t.Fatalf("expected reparent to detach from the old parent, got %v", got)
+	}
+	if err := ecs.NewRemoveParentCommand(medkit).Apply(world); err != nil {
+		t.Fatalf("remove parent: %v", err)
+	}
+	if _, ok := world.Parent(medkit); ok || len(world.Children(player)) != 0 {
+		t.Fatalf("expected medkit detached")
+	}
+}
+
+func TestDestroyRecursiveCascades(t *testing.T) {
+	world := ecs.NewWorld()
+	health := ecs.ComponentType("health")
+	if err := world.RegisterComponent(health, ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	var removed []ecs.EntityID
+	world.AddComponentHooks(health, ecs.ComponentHooks{OnRemove: func(_ *ecs.HookContext, event ecs.ComponentEvent) {
+		removed = append(removed, event.Entity)
+	}})
+
+	turret := world.Registry().Create()
+	shell := world.Registry().Create()
+	fragment := world.Registry().Create()
+	bystander := world.Registry().Create()
+	if err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(turret, health, 10),
+		ecs.NewAddComponentCommand(shell, health, 1),
+		ecs.NewAddComponentCommand(fragment, health, 1),
+		ecs.NewSetParentCommand(shell, turret),
+		ecs.NewSetParentCommand(fragment, shell),
+		ecs.NewDestroyRecursiveCommand(turret),
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	for _, id := range []ecs.EntityID{turret, shell, fragment} {
+		if world.Registry().IsAlive(id) {
+			t.Fatalf("expected %v destroyed with its parent", id)
+		}
+	}
+	if !world.Registry().IsAlive(bystander) {
+		t.Fatalf("expected unrelated entity to survive")
+	}
+	if !slices.Equal(removed, []ecs.EntityID{fragment, shell, turret}) {
+		t.Fatalf("expected children destroyed before parents, got %v", removed)
+	}
+}
+
+func TestDestroyEntityCascadesAcrossRecycling(t *testing.T) {
+	world := ecs.NewWorld()
+	parent := world.Registry().Create()
+	child := world.Registry().Create()
+	if err := world.ApplyCommands([]ecs.Command{
+		ecs.NewSetParentCommand(child, parent),
+		ecs.NewDestroyEntityCommand(parent),
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if world.Registry().IsAlive(child) {
+		t.Fatalf("expected the child destroyed with its parent")
+	}
+	if _, ok := world.Parent(child); ok {
+		t.Fatalf("expected the child's link dropped")
+	}
+
+	var recycled ecs.EntityID
+	for recycled.IsZero() || recycled.Index() != parent.Index() {
+		recycled = world.Registry().Create()
+	}
+	if recycled == parent {
+		t.Fatalf("expected slot reuse with a new generation")
+	}
+	if len(world.Children(recycled)) != 0 || len(world.Children(parent)) != 0 {
+		t.Fatalf("expected recycled slot to start without children")
+	}
+	if err := ecs.NewSetParentCommand(recycled, parent).Apply(world); err == nil {
+		t.Fatalf("expected stale parent to be rejected")
+	}
+	if err := ecs.NewDestroyRecursiveCommand(parent).Apply(world); err == nil {
+		t.Fatalf("expected stale root to be rejected")
+	}
+}
+
+func TestHierarchySurvivesSnapshot(t *testing.T) {
+	src := ecs.NewWorld()
+	root := src.Registry().Create()
+	a := src.Registry().Create()
+	b := src.Registry().Create()
+	if err := src.ApplyCommands([]ecs.Command{
+		ecs.NewSetParentCommand(b, root),
+		ecs.NewSetParentCommand(a, root),
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := src.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// The destination holds the same IDs with a conflicting link.
+	dst := ecs.NewWorld()
+	for range 3 {
+		dst.Registry().Create()
+	}
+	if err := ecs.NewSetParentCommand(root, a).Apply(dst); err != nil {
+		t.Fatalf("set parent: %v", err)
+	}
+	if err := dst.Restore(&buf); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if got := dst.Children(root); !slices.Equal(got, []ecs.EntityID{b, a}) {
+		t.Fatalf("expected restored children in attachment order, got %v", got)
+	}
+	if parent, ok := dst.Parent(a); !ok || parent != root {
+		t.Fatalf("expected restored parent link")
+	}
+	if _, ok := dst.Parent(root); ok {
+		t.Fatalf("expected links from before the restore to be dropped")
+	}
+}
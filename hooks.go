@@ -0,0 +1,124 @@
+package ecs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// maxHookFollowUpRounds bounds how many times follow-up commands queued by
+// hooks may trigger further follow-ups within a single flush.
+const maxHookFollowUpRounds = 64
+
+// ComponentEvent describes a component lifecycle change applied by a command.
+// Old is nil for additions and New is nil for removals.
+type ComponentEvent struct {
+	Entity    EntityID
+	Component ComponentType
+	Old       any
+	New       any
+}
+
+// ComponentHook reacts to a component lifecycle change.
+type ComponentHook func(ctx *HookContext, event ComponentEvent)
+
+// ComponentHooks groups the callbacks registered for one component type. Nil
+// callbacks are ignored.
+type ComponentHooks struct {
+	OnAdd    ComponentHook
+	OnChange ComponentHook
+	OnRemove ComponentHook
+}
+
+// HookContext is passed to hooks while commands are applied.
+type HookContext struct {
+	world *World
+}
+
+// World returns the world being mutated.
+func (c *HookContext) World() *World { return c.world }
+
+// Defer enqueues a follow-up command that applies after the current batch,
+// within the same ApplyCommands call.
+func (c *HookContext) Defer(cmd Command) {
+	c.world.hooks.enqueue(cmd)
+}
+
+// AddComponentHooks registers lifecycle hooks for t. Hooks fire only for
+// mutations made through commands; direct store writes bypass them. Multiple
+// registrations for the same type run in registration order.
+func (w *World) AddComponentHooks(t ComponentType, hooks ComponentHooks) {
+	w.hooks.add(t, hooks)
+}
+
+// componentHookRegistry stores hooks per type and the follow-up commands they
+// queue during a flush.
+type componentHookRegistry struct {
+	mu      sync.RWMutex
+	byType  map[ComponentType][]ComponentHooks
+	pending []Command
+}
+
+func (r *componentHookRegistry) add(t ComponentType, hooks ComponentHooks) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byType == nil {
+		r.byType = make(map[ComponentType][]ComponentHooks)
+	}
+	r.byType[t] = append(r.byType[t], hooks)
+}
+
+// lookup returns the hooks registered for t, or nil when there are none.
+func (r *componentHookRegistry) lookup(t ComponentType) []ComponentHooks {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byType[t]
+}
+
+func (r *componentHookRegistry) enqueue(cmd Command) {
+	if cmd == nil {
+		return
+	}
+	r.mu.Lock()
+	r.pending = append(r.pending, cmd)
+	r.mu.Unlock()
+}
+
+func (r *componentHookRegistry) drain() []Command {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending := r.pending
+	r.pending = nil
+	return pending
+}
+
+// fireComponentHooks invokes the matching callback of every hook set for the event.
+func (w *World) fireComponentHooks(hooks []ComponentHooks, event ComponentEvent, pick func(ComponentHooks) ComponentHook) {
+	ctx := &HookContext{world: w}
+	for _, set := range hooks {
+		if fn := pick(set); fn != nil {
+			fn(ctx, event)
+		}
+	}
+}
+
+// applyHookFollowUps applies commands queued by hooks with apply until none
+// remain.
+func (w *World) applyHookFollowUps(apply func([]Command) error) error {
+	for round := 0; ; round++ {
+		pending := w.hooks.drain()
+		if len(pending) == 0 {
+			return nil
+		}
+		if round >= maxHookFollowUpRounds {
+			return fmt.Errorf("%w: still queuing after %d rounds", ErrHookFollowUpLimit, maxHookFollowUpRounds)
+		}
+		if err := apply(pending); err != nil {
+			w.hooks.drain()
+			return err
+		}
+	}
+}
+
+func pickOnAdd(h ComponentHooks) ComponentHook    { return h.OnAdd }
+func pickOnChange(h ComponentHooks) ComponentHook { return h.OnChange }
+func pickOnRemove(h ComponentHooks) ComponentHook { return h.OnRemove }
@@ -0,0 +1,131 @@
+package ecs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+func TestComponentHooksFireForCommands(t *testing.T) {
+	world := ecs.NewWorld()
+	if err := world.RegisterComponent("Health", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	var events []string
+	var last ecs.ComponentEvent
+	record := func(kind string) ecs.ComponentHook {
+		return func(_ *ecs.HookContext, event ecs.ComponentEvent) {
+			events = append(events, kind)
+			last = event
+		}
+	}
+	world.AddComponentHooks("Health", ecs.ComponentHooks{
+		OnAdd:    record("add"),
+		OnChange: record("change"),
+		OnRemove: record("remove"),
+	})
+
+	id := world.Registry().Create()
+	apply := func(cmd ecs.Command) {
+		t.Helper()
+		if err := world.ApplyCommands([]ecs.Command{cmd}); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	apply(ecs.NewAddComponentCommand(id, "Health", 10))
+	if last.Entity != id || last.Old != nil || last.New != 10 {
+		t.Fatalf("unexpected add event: %+v", last)
+	}
+	apply(ecs.NewAddComponentCommand(id, "Health", 7))
+	if last.Old != 10 || last.New != 7 {
+		t.Fatalf("unexpected change event: %+v", last)
+	}
+	apply(ecs.NewRemoveComponentCommand(id, "Health"))
+	if last.Old != 7 || last.New != nil {
+		t.Fatalf("unexpected remove event: %+v", last)
+	}
+	apply(ecs.NewRemoveComponentCommand(id, "Health"))
+
+	if got := len(events); got != 3 || events[0] != "add" || events[1] != "change" || events[2] != "remove" {
+		t.Fatalf("unexpected events: %v", events)
+	}
+}
+
+func TestComponentHooksFollowUpCommandsApplyInSameFlush(t *testing.T) {
+	world := ecs.NewWorld()
+	_ = world.RegisterComponent("Handle", ecsstorage.NewDenseStrategy())
+	_ = world.RegisterComponent("Released", ecsstorage.NewDenseStrategy())
+
+	world.AddComponentHooks("Handle", ecs.ComponentHooks{
+		OnRemove: func(ctx *ecs.HookContext, event ecs.ComponentEvent) {
+			ctx.Defer(ecs.NewAddComponentCommand(event.Entity, "Released", event.Old))
+		},
+	})
+
+	id := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(id, "Handle", "socket-1"),
+		ecs.NewRemoveComponentCommand(id, "Handle"),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	released, _ := world.ViewComponent("Released")
+	if got, ok := released.Get(id); !ok || got != "socket-1" {
+		t.Fatalf("expected follow-up to apply in the same flush, got %v ok=%v", got, ok)
+	}
+}
+
+func TestComponentHooksFireOnDestroy(t *testing.T) {
+	world := ecs.NewWorld()
+	_ = world.RegisterComponent("Position", ecsstorage.NewDenseStrategy())
+
+	var removed []ecs.ComponentEvent
+	world.AddComponentHooks("Position", ecs.ComponentHooks{
+		OnRemove: func(ctx *ecs.HookContext, event ecs.ComponentEvent) {
+			if ctx.World().Registry().IsAlive(event.Entity) {
+				t.Errorf("entity should be dead when destroy hooks fire")
+			}
+			removed = append(removed, event)
+		},
+	})
+
+	id := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(id, "Position", 3),
+		ecs.NewDestroyEntityCommand(id),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(removed) != 1 || removed[0].Entity != id || removed[0].Old != 3 {
+		t.Fatalf("unexpected remove events: %+v", removed)
+	}
+}
+
+func TestComponentHooksFollowUpLimit(t *testing.T) {
+	world := ecs.NewWorld()
+	_ = world.RegisterComponent("Counter", ecsstorage.NewDenseStrategy())
+	world.AddComponentHooks("Counter", ecs.ComponentHooks{
+		OnChange: func(ctx *ecs.HookContext, event ecs.ComponentEvent) {
+			ctx.Defer(ecs.NewAddComponentCommand(event.Entity, "Counter", event.New.(int)+1))
+		},
+	})
+
+	id := world.Registry().Create()
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(id, "Counter", 0),
+		ecs.NewAddComponentCommand(id, "Counter", 1),
+	})
+	if !errors.Is(err, ecs.ErrHookFollowUpLimit) {
+		t.Fatalf("expected ErrHookFollowUpLimit, got %v", err)
+	}
+	// A failed flush must not leak queued follow-ups into the next one.
+	if err := world.ApplyCommands(nil); err != nil {
+		t.Fatalf("expected empty flush to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SystemInitializer is implemented by systems that need setup before their
+// first run. Init is called while the work group registers; an error rejects
+// the registration. Init must not call back into the scheduler.
+type SystemInitializer interface {
+	Init(world *World) error
+}
+
+// SystemShutdowner is implemented by systems that release resources when
+// their work group is unregistered or the scheduler shuts down.
+type SystemShutdowner interface {
+	Shutdown(ctx context.Context, world *World) error
+}
+
+// ShutdownPolicy selects what happens to commands produced by async work
+// groups that are still in flight when Shutdown is called.
+type ShutdownPolicy uint8
+
+const (
+	// ShutdownApplyCommands applies in-flight async commands before stopping.
+	ShutdownApplyCommands ShutdownPolicy = iota
+	// ShutdownDiscardCommands drops in-flight async commands.
+	ShutdownDiscardCommands
+)
+
+func (b *schedulerBuilder) WithShutdownPolicy(policy ShutdownPolicy) SchedulerBuilder {
+	b.scheduler.mu.Lock()
+	b.scheduler.shutdownPolicy = policy
+	b.scheduler.mu.Unlock()
+	return b
+}
+
+// Shutdown stops the scheduler: it waits for a running tick and its async
+// groups, including SpanTicks groups still in flight, closes the worker pool
+// and calls SystemShutdowner callbacks in reverse execution order. Afterwards
+// Tick returns ErrSchedulerClosed. If ctx ends before the running tick does,
+// Shutdown returns and may be called again. It must not be called from a
+// running system.
+func (s *basicScheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closing = true
+	idle := s.tickDone
+	s.mu.Unlock()
+
+	if idle != nil {
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return fmt.Errorf("ecs: shutdown: %w", ctx.Err())
+		}
+	}
+
+	if err := s.drainSpanning(ctx); err != nil {
+		return fmt.Errorf("ecs: shutdown: %w", err)
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.applyPendingControlLocked()
+	detached := s.takeDetachedLocked()
+	pool := s.asyncPool
+	s.asyncPool = nil
+	groups := append([]*workGroupState(nil), s.orderedGroups...)
+	world := s.world
+	s.mu.Unlock()
+
+	pool.Close()
+	errs := []error{shutdownDetached(ctx, detached, world)}
+	for i := len(groups) - 1; i >= 0; i-- {
+		errs = append(errs, shutdownSystems(ctx, groups[i], world))
+	}
+	return errors.Join(errs...)
+}
+
+// discardSpanningCommands reports whether a finished SpanTicks job's commands
+// should be dropped because the scheduler is shutting down or the group was
+// unregistered while the job ran, and the shutdown policy discards them.
+func (s *basicScheduler) discardSpanningCommands(group *workGroupState) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.shutdownPolicy != ShutdownDiscardCommands {
+		return false
+	}
+	return s.closing || s.groupStates[group.id] != group
+}
+
+// discardAsyncCommands reports whether a tick should drop async results
+// because Shutdown was requested with ShutdownDiscardCommands.
+func (s *basicScheduler) discardAsyncCommands() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.closing && s.shutdownPolicy == ShutdownDiscardCommands
+}
+
+// initSystems runs SystemInitializer callbacks in order. On failure the
+// systems already initialized are shut down again.
+func initSystems(group WorkGroupID, systems []System, world *World) error {
+	for i, sys := range systems {
+		initializer, ok := sys.(SystemInitializer)
+		if !ok {
+			continue
+		}
+		if err := initializer.Init(world); err != nil {
+			rollback := &workGroupState{id: group, systems: systems[:i]}
+			return errors.Join(
+				fmt.Errorf("ecs: init system %s in work group %s: %w", sys.Descriptor().Name, group, err),
+				shutdownSystems(context.Background(), rollback, world),
+			)
+		}
+	}
+	return nil
+}
+
+// shutdownSystems runs SystemShutdowner callbacks in reverse order.
+func shutdownSystems(ctx context.Context, group *workGroupState, world *World) error {
+	var errs []error
+	for i := len(group.systems) - 1; i >= 0; i-- {
+		sys := group.systems[i]
+		shutdowner, ok := sys.(SystemShutdowner)
+		if !ok {
+			continue
+		}
+		if err := shutdowner.Shutdown(ctx, world); err != nil {
+			errs = append(errs, fmt.Errorf("ecs: shutdown system %s in work group %s: %w", sys.Descriptor().Name, group.id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// takeDetachedLocked hands over groups unregistered since the last call so
+// their shutdown callbacks can run without holding the scheduler lock. Groups
+// whose SpanTicks job is still running move to retiring instead, and shut down
+// once the job has finished.
+func (s *basicScheduler) takeDetachedLocked() []*workGroupState {
+	var idle []*workGroupState
+	for _, group := range s.detached {
+		if group.inflight != nil {
+			s.retiring = append(s.retiring, group)
+			continue
+		}
+		idle = append(idle, group)
+	}
+	s.detached = nil
+	return idle
+}
+
+func shutdownDetached(ctx context.Context, groups []*workGroupState, world *World) error {
+	var errs []error
+	for _, group := range groups {
+		if err := shutdownSystems(ctx, group, world); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
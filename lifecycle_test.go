@@ -0,0 +1,168 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+type lifecycleSystem struct {
+	name    string
+	log     *[]string
+	initErr error
+}
+
+func (s *lifecycleSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: s.name}
+}
+
+func (s *lifecycleSystem) Run(context.Context, ecs.ExecutionContext) ecs.SystemResult {
+	return ecs.SystemResult{}
+}
+
+func (s *lifecycleSystem) Init(*ecs.World) error {
+	*s.log = append(*s.log, "init "+s.name)
+	return s.initErr
+}
+
+func (s *lifecycleSystem) Shutdown(context.Context, *ecs.World) error {
+	*s.log = append(*s.log, "shutdown "+s.name)
+	return nil
+}
+
+func TestSchedulerLifecycleCallbacks(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	var log []string
+	sys := func(name string) *lifecycleSystem { return &lifecycleSystem{name: name, log: &log} }
+
+	broken := sys("broken")
+	broken.initErr = errors.New("no assets")
+	_, err = scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "menu", Systems: []ecs.System{sys("ui"), broken}})
+	if err == nil || !errors.Is(err, broken.initErr) {
+		t.Fatalf("expected init failure, got %v", err)
+	}
+	for _, cfg := range []ecs.WorkGroupConfig{
+		{ID: "core", Systems: []ecs.System{sys("input"), sys("physics")}},
+		{ID: "mode", Systems: []ecs.System{sys("rules")}},
+		{ID: "hud", Systems: []ecs.System{sys("hud")}},
+	} {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+	if err := scheduler.UnregisterWorkGroup("mode"); err != nil {
+		t.Fatalf("unregister: %v", err)
+	}
+	if err := scheduler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	want := []string{
+		"init ui", "init broken", "shutdown ui",
+		"init input", "init physics", "init rules", "init hud",
+		"shutdown rules",
+		"shutdown hud", "shutdown physics", "shutdown input",
+	}
+	if len(log) != len(want) {
+		t.Fatalf("unexpected lifecycle %v", log)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Fatalf("unexpected lifecycle %v", log)
+		}
+	}
+}
+
+func TestSchedulerClosedAfterShutdown(t *testing.T) {
+	before := runtime.NumGoroutine()
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	scheduler.Builder().WithAsyncWorkers(4)
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if err := scheduler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if err := scheduler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("second shutdown should be a no-op: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); !errors.Is(err, ecs.ErrSchedulerClosed) {
+		t.Fatalf("expected ErrSchedulerClosed from Tick, got %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "late"}); !errors.Is(err, ecs.ErrSchedulerClosed) {
+		t.Fatalf("expected ErrSchedulerClosed from RegisterWorkGroup, got %v", err)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("worker goroutines leaked: %d before, %d after", before, after)
+	}
+}
+
+// blockingAsyncSystem creates an entity once released, signalling when it starts.
+func blockingAsyncSystem(started chan<- struct{}, release <-chan struct{}) *testSystem {
+	return &testSystem{
+		name: "export",
+		desc: ecs.SystemDescriptor{AsyncAllowed: true},
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			close(started)
+			<-release
+			ctx.Defer(ecs.NewCreateEntityCommand(nil))
+		},
+	}
+}
+
+func TestShutdownDrainsInFlightAsyncGroups(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		policy   ecs.ShutdownPolicy
+		entities int
+	}{
+		{"apply", ecs.ShutdownApplyCommands, 1},
+		{"discard", ecs.ShutdownDiscardCommands, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			world := ecs.NewWorld()
+			scheduler, err := ecs.NewScheduler(world)
+			if err != nil {
+				t.Fatalf("new scheduler: %v", err)
+			}
+			scheduler.Builder().WithAsyncWorkers(1).WithShutdownPolicy(tc.policy)
+			started, release := make(chan struct{}), make(chan struct{})
+			if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "export", Mode: ecs.WorkGroupModeAsync, Systems: []ecs.System{blockingAsyncSystem(started, release)}}); err != nil {
+				t.Fatalf("register: %v", err)
+			}
+
+			tickErr := make(chan error, 1)
+			go func() { tickErr <- scheduler.Tick(context.Background(), time.Millisecond) }()
+			<-started
+
+			expired, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := scheduler.Shutdown(expired); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected shutdown to time out while the tick runs, got %v", err)
+			}
+
+			shutdownErr := make(chan error, 1)
+			go func() { shutdownErr <- scheduler.Shutdown(context.Background()) }()
+			close(release)
+			if err := <-tickErr; err != nil {
+				t.Fatalf("tick: %v", err)
+			}
+			if err := <-shutdownErr; err != nil {
+				t.Fatalf("shutdown: %v", err)
+			}
+			if got := world.Registry().Count(); got != tc.entities {
+				t.Fatalf("expected %d entities after shutdown, got %d", tc.entities, got)
+			}
+		})
+	}
+}
@@ -21,6 +21,14 @@ func (c compositeObserver) WorkGroupCompleted(summary WorkGroupSummary) {
 	}
 }
 
+func (c compositeObserver) SystemCircuitOpened(event CircuitEvent) {
+	for _, observer := range c.observers {
+		if circuits, ok := observer.(CircuitObserver); ok {
+			circuits.SystemCircuitOpened(event)
+		}
+	}
+}
+
 type loggingObserver struct {
 	logger Logger
 	format ObservationLogFormat
@@ -51,6 +59,7 @@ func (o loggingObserver) logJSON(summary WorkGroupSummary) {
 		"mode":             summary.Mode,
 		"async":            summary.Async,
 		"tick":             summary.Tick,
+		"staleness_ticks":  summary.Staleness,
 		"duration_ms":      float64(summary.Duration) / float64(time.Millisecond),
 		"systems_total":    summary.SystemsTotal,
 		"systems_executed": summary.SystemsExecuted,
@@ -59,6 +68,7 @@ func (o loggingObserver) logJSON(summary WorkGroupSummary) {
 		"component_writes": summary.ComponentWrites,
 		"resource_reads":   summary.ResourceReads,
 		"resource_writes":  summary.ResourceWrites,
+		"systems":          systemSummaryPayloads(summary.Systems),
 	}
 	if summary.Error != nil {
 		payload["error"] = summary.Error.Error()
@@ -77,6 +87,7 @@ func (o loggingObserver) logKeyValue(summary WorkGroupSummary) {
 		"mode", summary.Mode,
 		"async", summary.Async,
 		"tick", summary.Tick,
+		"staleness_ticks", summary.Staleness,
 		"duration", summary.Duration,
 		"systems_total", summary.SystemsTotal,
 		"systems_executed", summary.SystemsExecuted,
@@ -90,6 +101,52 @@ func (o loggingObserver) logKeyValue(summary WorkGroupSummary) {
 		args = append(args, "error", summary.Error.Error())
 	}
 	builder.Info("workgroup summary", args...)
+	for _, system := range summary.Systems {
+		systemArgs := []any{
+			"tick", summary.Tick,
+			"status", system.Status.String(),
+			"duration", system.Duration,
+			"retries", system.Retries,
+			"commands", system.Commands,
+		}
+		if system.Error != nil {
+			systemArgs = append(systemArgs, "error", system.Error.Error())
+		}
+		builder.With("system", system.Name).Info("system summary", systemArgs...)
+	}
+}
+
+func systemSummaryPayloads(systems []SystemSummary) []map[string]any {
+	if len(systems) == 0 {
+		return nil
+	}
+	out := make([]map[string]any, 0, len(systems))
+	for _, system := range systems {
+		entry := map[string]any{
+			"name":        system.Name,
+			"status":      system.Status.String(),
+			"duration_ms": float64(system.Duration) / float64(time.Millisecond),
+			"retries":     system.Retries,
+			"commands":    system.Commands,
+		}
+		if system.Error != nil {
+			entry["error"] = system.Error.Error()
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func (o loggingObserver) SystemCircuitOpened(event CircuitEvent) {
+	args := []any{
+		"system", event.System,
+		"tick", event.Tick,
+		"failures", event.Failures,
+	}
+	if event.Error != nil {
+		args = append(args, "error", event.Error.Error())
+	}
+	o.logger.With("work_group", event.WorkGroupID).Error("system circuit opened", args...)
 }
 
 type prometheusObserver struct {
@@ -184,6 +241,20 @@ type PrometheusWorkGroupCollector struct {
 	options *PrometheusCollectorOptions
 	mu      sync.Mutex
 	samples map[prometheusKey]*prometheusSample
+	systems map[prometheusSystemKey]*prometheusSystemSample
+}
+
+type prometheusSystemKey struct {
+	WorkGroupID string
+	System      string
+}
+
+type prometheusSystemSample struct {
+	durationSum float64
+	buckets     []float64
+	statuses    [3]float64 // indexed by SystemStatus
+	retries     float64
+	commands    float64
 }
 
 type prometheusKey struct {
@@ -199,6 +270,8 @@ type prometheusSample struct {
 	executed      float64
 	skipped       float64
 	errors        float64
+	stalenessSum  float64
+	stalenessMax  float64
 }
 
 func NewPrometheusWorkGroupCollector(opts *PrometheusCollectorOptions) PrometheusCollector {
@@ -208,6 +281,7 @@ func NewPrometheusWorkGroupCollector(opts *PrometheusCollectorOptions) Prometheu
 	return &PrometheusWorkGroupCollector{
 		options: opts,
 		samples: make(map[prometheusKey]*prometheusSample),
+		systems: make(map[prometheusSystemKey]*prometheusSystemSample),
 	}
 }
 
@@ -237,12 +311,45 @@ func (c *PrometheusWorkGroupCollector) ObserveWorkGroup(summary WorkGroupSummary
 	if summary.Error != nil {
 		sample.errors++
 	}
+	staleness := float64(summary.Staleness)
+	sample.stalenessSum += staleness
+	if staleness > sample.stalenessMax {
+		sample.stalenessMax = staleness
+	}
+	for _, system := range summary.Systems {
+		c.observeSystemLocked(string(summary.WorkGroupID), system)
+	}
 
 	if writer := c.options.Writer; writer != nil {
 		_ = c.writeMetricsLocked(writer)
 	}
 }
 
+func (c *PrometheusWorkGroupCollector) observeSystemLocked(group string, system SystemSummary) {
+	key := prometheusSystemKey{WorkGroupID: group, System: system.Name}
+	sample, ok := c.systems[key]
+	if !ok {
+		sample = &prometheusSystemSample{}
+		if buckets := c.options.DurationBuckets; len(buckets) > 0 {
+			sample.buckets = make([]float64, len(buckets))
+		}
+		c.systems[key] = sample
+	}
+	sample.statuses[system.Status]++
+	sample.retries += float64(system.Retries)
+	sample.commands += float64(system.Commands)
+	if system.Status == SystemStatusSkipped && system.Duration == 0 {
+		return
+	}
+	durSeconds := system.Duration.Seconds()
+	sample.durationSum += durSeconds
+	for i := range sample.buckets {
+		if durSeconds <= c.options.DurationBuckets[i].Seconds() {
+			sample.buckets[i]++
+		}
+	}
+}
+
 func (c *PrometheusWorkGroupCollector) WriteMetrics(w io.Writer) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -307,10 +414,77 @@ func (c *PrometheusWorkGroupCollector) writeMetricsLocked(w io.Writer) error {
 		buf.WriteString(fmt.Sprintf("ecs_work_group_errors_total{%s} %f\n", labels, sample.errors))
 	}
 
+	buf.WriteString("# HELP ecs_work_group_staleness_ticks Ticks between dispatch and command application.\n")
+	buf.WriteString("# TYPE ecs_work_group_staleness_ticks summary\n")
+	for _, key := range keys {
+		sample := c.samples[key]
+		labels := fmt.Sprintf("work_group_id=\"%s\",mode=\"%s\",async=\"%t\"", key.WorkGroupID, key.Mode, key.Async)
+		buf.WriteString(fmt.Sprintf("ecs_work_group_staleness_ticks_sum{%s} %f\n", labels, sample.stalenessSum))
+		buf.WriteString(fmt.Sprintf("ecs_work_group_staleness_ticks_count{%s} %f\n", labels, sample.durationCount))
+		buf.WriteString(fmt.Sprintf("ecs_work_group_staleness_ticks_max{%s} %f\n", labels, sample.stalenessMax))
+	}
+
+	c.writeSystemMetricsLocked(&buf)
+
 	_, err := w.Write(buf.Bytes())
 	return err
 }
 
+func (c *PrometheusWorkGroupCollector) writeSystemMetricsLocked(buf *bytes.Buffer) {
+	if len(c.systems) == 0 {
+		return
+	}
+	keys := make([]prometheusSystemKey, 0, len(c.systems))
+	for key := range c.systems {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].WorkGroupID == keys[j].WorkGroupID {
+			return keys[i].System < keys[j].System
+		}
+		return keys[i].WorkGroupID < keys[j].WorkGroupID
+	})
+	systemLabels := func(key prometheusSystemKey) string {
+		return fmt.Sprintf("work_group_id=\"%s\",system=\"%s\"", key.WorkGroupID, key.System)
+	}
+
+	buf.WriteString("# HELP ecs_system_duration_seconds System execution duration, including retries.\n")
+	buf.WriteString("# TYPE ecs_system_duration_seconds summary\n")
+	for _, key := range keys {
+		sample := c.systems[key]
+		labels := systemLabels(key)
+		ran := sample.statuses[SystemStatusExecuted] + sample.statuses[SystemStatusFailed]
+		buf.WriteString(fmt.Sprintf("ecs_system_duration_seconds_sum{%s} %f\n", labels, sample.durationSum))
+		buf.WriteString(fmt.Sprintf("ecs_system_duration_seconds_count{%s} %f\n", labels, ran))
+		for i, bucket := range sample.buckets {
+			le := c.options.DurationBuckets[i].Seconds()
+			buf.WriteString(fmt.Sprintf("ecs_system_duration_seconds_bucket{%s,le=\"%.6f\"} %f\n", labels, le, bucket))
+		}
+	}
+
+	buf.WriteString("# HELP ecs_system_runs_total System runs by outcome.\n")
+	buf.WriteString("# TYPE ecs_system_runs_total counter\n")
+	for _, key := range keys {
+		sample := c.systems[key]
+		labels := systemLabels(key)
+		for _, status := range []SystemStatus{SystemStatusExecuted, SystemStatusSkipped, SystemStatusFailed} {
+			buf.WriteString(fmt.Sprintf("ecs_system_runs_total{%s,status=\"%s\"} %f\n", labels, status, sample.statuses[status]))
+		}
+	}
+
+	buf.WriteString("# HELP ecs_system_retries_total System retry attempts.\n")
+	buf.WriteString("# TYPE ecs_system_retries_total counter\n")
+	for _, key := range keys {
+		buf.WriteString(fmt.Sprintf("ecs_system_retries_total{%s} %f\n", systemLabels(key), c.systems[key].retries))
+	}
+
+	buf.WriteString("# HELP ecs_system_commands_total Commands enqueued by a system.\n")
+	buf.WriteString("# TYPE ecs_system_commands_total counter\n")
+	for _, key := range keys {
+		buf.WriteString(fmt.Sprintf("ecs_system_commands_total{%s} %f\n", systemLabels(key), c.systems[key].commands))
+	}
+}
+
 type SigNozSpanExporter struct {
 	opts *SigNozOptions
 	mu   sync.Mutex
@@ -330,16 +504,21 @@ func (e *SigNozSpanExporter) ExportWorkGroup(summary WorkGroupSummary) {
 	if e.opts.Writer == nil {
 		return
 	}
+	// timestamp stays the export (end) time; start_timestamp places the span.
+	end := time.Now().UnixNano()
+	start := end - summary.Duration.Nanoseconds()
 	span := map[string]any{
-		"service_name": e.opts.ServiceName,
-		"name":         fmt.Sprintf("workgroup:%s", summary.WorkGroupID),
-		"timestamp":    time.Now().UnixNano(),
-		"duration_ms":  float64(summary.Duration) / float64(time.Millisecond),
+		"service_name":    e.opts.ServiceName,
+		"name":            fmt.Sprintf("workgroup:%s", summary.WorkGroupID),
+		"timestamp":       end,
+		"start_timestamp": start,
+		"duration_ms":     float64(summary.Duration) / float64(time.Millisecond),
 		"attributes": map[string]any{
 			"work_group_id":    summary.WorkGroupID,
 			"mode":             modeLabel(summary.Mode),
 			"async":            summary.Async,
 			"tick":             summary.Tick,
+			"staleness_ticks":  summary.Staleness,
 			"systems_total":    summary.SystemsTotal,
 			"systems_executed": summary.SystemsExecuted,
 			"systems_skipped":  summary.SystemsSkipped,
@@ -356,9 +535,47 @@ func (e *SigNozSpanExporter) ExportWorkGroup(summary WorkGroupSummary) {
 	if err != nil {
 		return
 	}
+	lines := append(payload, '\n')
+
+	// Child spans are laid out back to back from the group start, which keeps
+	// them ordered and nested even though only durations are recorded.
+	parent := span["name"]
+	offset := start
+	for _, system := range summary.Systems {
+		if system.Status == SystemStatusSkipped && system.Duration == 0 {
+			continue
+		}
+		child := map[string]any{
+			"service_name":    e.opts.ServiceName,
+			"name":            fmt.Sprintf("system:%s", system.Name),
+			"parent":          parent,
+			"timestamp":       offset + system.Duration.Nanoseconds(),
+			"start_timestamp": offset,
+			"duration_ms":     float64(system.Duration) / float64(time.Millisecond),
+			"attributes": map[string]any{
+				"work_group_id": summary.WorkGroupID,
+				"system":        system.Name,
+				"tick":          summary.Tick,
+				"status":        system.Status.String(),
+				"retries":       system.Retries,
+				"commands":      system.Commands,
+			},
+		}
+		if system.Error != nil {
+			child["error"] = system.Error.Error()
+		}
+		offset += system.Duration.Nanoseconds()
+		data, err := json.Marshal(child)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, data...)
+		lines = append(lines, '\n')
+	}
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	_, _ = e.opts.Writer.Write(append(payload, '\n'))
+	_, _ = e.opts.Writer.Write(lines)
 }
 
 func modeLabel(mode WorkGroupMode) string {
@@ -3,6 +3,7 @@ package ecs
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -24,6 +25,10 @@ func TestPrometheusWorkGroupCollectorWritesMetrics(t *testing.T) {
 		SystemsTotal:    2,
 		SystemsExecuted: 2,
 		SystemsSkipped:  0,
+		Systems: []SystemSummary{
+			{Name: "move", Status: SystemStatusExecuted, Duration: 4 * time.Millisecond, Commands: 3},
+			{Name: "sync", Status: SystemStatusFailed, Duration: time.Millisecond, Retries: 1, Error: errors.New("boom")},
+		},
 	}
 
 	collector.ObserveWorkGroup(summary)
@@ -39,6 +44,15 @@ func TestPrometheusWorkGroupCollectorWritesMetrics(t *testing.T) {
 	if !strings.Contains(metrics, "ecs_work_group_systems_executed_total") {
 		t.Fatalf("expected executed metric in %q", metrics)
 	}
+	for _, want := range []string{
+		`ecs_system_commands_total{work_group_id="wg",system="move"} 3.000000`,
+		`ecs_system_runs_total{work_group_id="wg",system="sync",status="failed"} 1.000000`,
+		`ecs_system_retries_total{work_group_id="wg",system="sync"} 1.000000`,
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Fatalf("expected %q in %q", want, metrics)
+		}
+	}
 }
 
 func TestSigNozSpanExporterWritesJSON(t *testing.T) {
@@ -74,3 +88,44 @@ func TestSigNozSpanExporterWritesJSON(t *testing.T) {
 		t.Fatalf("unexpected work_group_id: %v", attrs["work_group_id"])
 	}
 }
+
+func TestSigNozSpanExporterWritesSystemSpans(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := NewSigNozSpanExporter(&SigNozOptions{Writer: &buf, ServiceName: "ecs-test"})
+	summary := WorkGroupSummary{
+		WorkGroupID: "wg",
+		Duration:    10 * time.Millisecond,
+		Systems: []SystemSummary{
+			{Name: "report", Status: SystemStatusExecuted, Duration: 10 * time.Millisecond},
+			{Name: "idle", Status: SystemStatusSkipped},
+		},
+	}
+
+	before := float64(time.Now().UnixNano())
+	exporter.ExportWorkGroup(summary)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected a group span and one child span, got %d lines", len(lines))
+	}
+	var group, child map[string]any
+	if err := json.Unmarshal(lines[0], &group); err != nil {
+		t.Fatalf("invalid group json: %v", err)
+	}
+	if err := json.Unmarshal(lines[1], &child); err != nil {
+		t.Fatalf("invalid child json: %v", err)
+	}
+	if child["name"] != "system:report" || child["parent"] != "workgroup:wg" {
+		t.Fatalf("unexpected child span: %v", child)
+	}
+
+	// timestamp keeps its end-of-span meaning; start_timestamp is separate.
+	end, _ := group["timestamp"].(float64)
+	start, _ := group["start_timestamp"].(float64)
+	if end < before-1e3 || end-start < 9e6 || end-start > 11e6 {
+		t.Fatalf("unexpected group timestamps end=%f start=%f (export began %f)", end, start, before)
+	}
+	if child["start_timestamp"] != group["start_timestamp"] || child["timestamp"] != group["timestamp"] {
+		t.Fatalf("expected the only child to span the group: %v", child)
+	}
+}
@@ -0,0 +1,165 @@
+package ecs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// orderNode describes one schedulable item for constraint sorting. Names holds
+// every identifier that Before/After references of other nodes may use.
+type orderNode struct {
+	name   string
+	names  []string
+	before []string
+	after  []string
+}
+
+// orderEdges resolves Before/After references into successor lists. Unknown
+// references are ignored so optional plugins can be absent.
+func orderEdges(nodes []orderNode) [][]int {
+	byName := make(map[string][]int)
+	for i, node := range nodes {
+		for _, name := range node.names {
+			if name == "" {
+				continue
+			}
+			byName[name] = append(byName[name], i)
+		}
+	}
+
+	succ := make([][]int, len(nodes))
+	seen := make([]map[int]struct{}, len(nodes))
+	link := func(from, to int) {
+		if from == to {
+			return
+		}
+		if seen[from] == nil {
+			seen[from] = make(map[int]struct{})
+		}
+		if _, ok := seen[from][to]; ok {
+			return
+		}
+		seen[from][to] = struct{}{}
+		succ[from] = append(succ[from], to)
+	}
+	for i, node := range nodes {
+		for _, ref := range node.before {
+			for _, j := range byName[ref] {
+				link(i, j)
+			}
+		}
+		for _, ref := range node.after {
+			for _, j := range byName[ref] {
+				link(j, i)
+			}
+		}
+	}
+	return succ
+}
+
+// topoOrder returns node indices honoring the declared constraints. Among nodes
+// whose predecessors have all been placed, the earliest in the input wins, so
+// unconstrained nodes keep their original relative order.
+func topoOrder(nodes []orderNode) ([]int, [][]int, error) {
+	succ := orderEdges(nodes)
+	indegree := make([]int, len(nodes))
+	for _, targets := range succ {
+		for _, j := range targets {
+			indegree[j]++
+		}
+	}
+
+	placed := make([]bool, len(nodes))
+	order := make([]int, 0, len(nodes))
+	for len(order) < len(nodes) {
+		next := -1
+		for i := range nodes {
+			if !placed[i] && indegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, nil, fmt.Errorf("%w: %s", ErrOrderingCycle, describeCycle(nodes, succ, placed))
+		}
+		placed[next] = true
+		order = append(order, next)
+		for _, j := range succ[next] {
+			indegree[j]--
+		}
+	}
+	return order, succ, nil
+}
+
+// describeCycle walks unplaced nodes until one repeats and renders the loop.
+func describeCycle(nodes []orderNode, succ [][]int, placed []bool) string {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make([]int, len(nodes))
+	var stack []int
+	var cycle []int
+	var visit func(int) bool
+	visit = func(i int) bool {
+		state[i] = active
+		stack = append(stack, i)
+		for _, j := range succ[i] {
+			if placed[j] {
+				continue
+			}
+			if state[j] == active {
+				for k := len(stack) - 1; k >= 0; k-- {
+					if stack[k] == j {
+						cycle = append(append([]int(nil), stack[k:]...), j)
+						return true
+					}
+				}
+			}
+			if state[j] == unvisited && visit(j) {
+				return true
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = done
+		return false
+	}
+	for i := range nodes {
+		if !placed[i] && state[i] == unvisited && visit(i) {
+			break
+		}
+	}
+	parts := make([]string, len(cycle))
+	for i, idx := range cycle {
+		parts[i] = nodes[idx].name
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// orderSystems sorts a work group's systems by their declared constraints.
+func orderSystems(group WorkGroupID, systems []System) ([]System, error) {
+	nodes := make([]orderNode, len(systems))
+	for i, sys := range systems {
+		desc := sys.Descriptor()
+		name := desc.Name
+		if name == "" {
+			name = "<unnamed>"
+		}
+		nodes[i] = orderNode{
+			name:   name,
+			names:  append([]string{desc.Name}, desc.Labels...),
+			before: desc.Before,
+			after:  desc.After,
+		}
+	}
+	order, _, err := topoOrder(nodes)
+	if err != nil {
+		return nil, fmt.Errorf("ecs: work group %s: %w", group, err)
+	}
+	out := make([]System, len(order))
+	for i, idx := range order {
+		out[i] = systems[idx]
+	}
+	return out, nil
+}
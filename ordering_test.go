@@ -0,0 +1,150 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+func TestSchedulerOrdersSystemsByConstraints(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	order := make([]string, 0)
+	render := &testSystem{name: "render", executed: &order, desc: ecs.SystemDescriptor{After: []string{"physics"}}}
+	input := &testSystem{name: "input", executed: &order, desc: ecs.SystemDescriptor{Before: []string{"simulation"}}}
+	move := &testSystem{name: "move", executed: &order, desc: ecs.SystemDescriptor{Labels: []string{"physics", "simulation"}}}
+	audio := &testSystem{name: "audio", executed: &order, desc: ecs.SystemDescriptor{After: []string{"missing-plugin"}}}
+
+	_, err = scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{
+		ID:      "frame",
+		Systems: []ecs.System{render, audio, move, input},
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	want := []string{"audio", "input", "move", "render"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected order: got %v want %v", order, want)
+	}
+}
+
+func TestSchedulerOrdersGroupsAcrossRegistrations(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	order := make([]string, 0)
+	register := func(cfg ecs.WorkGroupConfig) {
+		t.Helper()
+		cfg.Systems = []ecs.System{&testSystem{name: string(cfg.ID), executed: &order}}
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+	register(ecs.WorkGroupConfig{ID: "render", After: []string{"simulation"}})
+	register(ecs.WorkGroupConfig{ID: "physics", Labels: []string{"simulation"}})
+	register(ecs.WorkGroupConfig{ID: "plugin", Before: []string{"physics"}})
+
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	want := []string{"plugin", "physics", "render"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected order: got %v want %v", order, want)
+	}
+}
+
+func TestSchedulerRejectsSystemOrderingCycle(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	_, err = scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{
+		ID: "loop",
+		Systems: []ecs.System{
+			&testSystem{name: "a", desc: ecs.SystemDescriptor{Before: []string{"b"}}},
+			&testSystem{name: "b", desc: ecs.SystemDescriptor{Before: []string{"c"}}},
+			&testSystem{name: "c", desc: ecs.SystemDescriptor{Before: []string{"a"}}},
+		},
+	})
+	if !errors.Is(err, ecs.ErrOrderingCycle) {
+		t.Fatalf("expected ErrOrderingCycle, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Fatalf("expected full cycle path, got %v", err)
+	}
+}
+
+func TestSchedulerRejectsGroupOrderingCycle(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+
+	order := make([]string, 0)
+	first := ecs.WorkGroupConfig{ID: "first", Before: []string{"second"}, Systems: []ecs.System{&testSystem{name: "first", executed: &order}}}
+	second := ecs.WorkGroupConfig{ID: "second", Before: []string{"first"}, Systems: []ecs.System{&testSystem{name: "second", executed: &order}}}
+	if _, err := scheduler.RegisterWorkGroup(first); err != nil {
+		t.Fatalf("register first: %v", err)
+	}
+	_, err = scheduler.RegisterWorkGroup(second)
+	if !errors.Is(err, ecs.ErrOrderingCycle) {
+		t.Fatalf("expected ErrOrderingCycle, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "first -> second -> first") {
+		t.Fatalf("expected full cycle path, got %v", err)
+	}
+
+	// The rejected group must not linger in the schedule.
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if len(order) != 1 || order[0] != "first" {
+		t.Fatalf("unexpected order after rejected registration: %v", order)
+	}
+	second.Before = nil
+	if _, err := scheduler.RegisterWorkGroup(second); err != nil {
+		t.Fatalf("re-register second: %v", err)
+	}
+}
+
+func TestSchedulerRejectsOrderingAgainstAsyncGroups(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	async := ecs.WorkGroupConfig{
+		ID:      "pathing",
+		Mode:    ecs.WorkGroupModeAsync,
+		Labels:  []string{"ai"},
+		Systems: []ecs.System{&testSystem{name: "paths", desc: ecs.SystemDescriptor{AsyncAllowed: true}}},
+	}
+	if _, err := scheduler.RegisterWorkGroup(async); err != nil {
+		t.Fatalf("register async: %v", err)
+	}
+	sync := ecs.WorkGroupConfig{ID: "movement", After: []string{"ai"}, Systems: []ecs.System{&testSystem{name: "move"}}}
+	_, err = scheduler.RegisterWorkGroup(sync)
+	if !errors.Is(err, ecs.ErrAsyncOrdering) {
+		t.Fatalf("expected ErrAsyncOrdering, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "pathing before movement") {
+		t.Fatalf("expected the offending edge in %v", err)
+	}
+	sync.After = nil
+	if _, err := scheduler.RegisterWorkGroup(sync); err != nil {
+		t.Fatalf("register without constraint: %v", err)
+	}
+}
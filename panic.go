@@ -0,0 +1,48 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// SystemPanicError reports a panic recovered from a system or worker job.
+// It matches ErrSystemPanic with errors.Is and, when the panic value is an
+// error, unwraps to that error as well.
+type SystemPanicError struct {
+	System    string
+	WorkGroup WorkGroupID
+	Tick      uint64
+	Value     any
+	Stack     []byte
+}
+
+func (e *SystemPanicError) Error() string {
+	if e.System == "" {
+		return fmt.Sprintf("ecs: worker job panicked: %v", e.Value)
+	}
+	return fmt.Sprintf("ecs: system %s in work group %s panicked at tick %d: %v", e.System, e.WorkGroup, e.Tick, e.Value)
+}
+
+func (e *SystemPanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrSystemPanic, err}
+	}
+	return []error{ErrSystemPanic}
+}
+
+// runSystem invokes the system, converting a panic into a failed result.
+func runSystem(ctx context.Context, system System, exec *systemExecutionContext, name string, group WorkGroupID) (result SystemResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = SystemResult{Err: &SystemPanicError{
+				System:    name,
+				WorkGroup: group,
+				Tick:      exec.tick,
+				Value:     r,
+				Stack:     debug.Stack(),
+			}}
+		}
+	}()
+	return system.Run(ctx, exec)
+}
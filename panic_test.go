@@ -0,0 +1,101 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+// panickingSystem defers a command and then panics for its first failures runs.
+func panickingSystem(name string, failures int, created *ecs.EntityID) *testSystem {
+	runs := 0
+	return &testSystem{
+		name: name,
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			ctx.Defer(ecs.NewCreateEntityCommand(created))
+			runs++
+			if runs <= failures {
+				panic("system exploded")
+			}
+		},
+	}
+}
+
+func TestSchedulerRecoversSystemPanic(t *testing.T) {
+	world := ecs.NewWorld()
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	observer := &recordingObserver{}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{Observer: observer})
+
+	var created ecs.EntityID
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "physics", Systems: []ecs.System{panickingSystem("integrate", 1, &created)}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	err = scheduler.Tick(context.Background(), time.Millisecond)
+	var panicErr *ecs.SystemPanicError
+	if !errors.As(err, &panicErr) || !errors.Is(err, ecs.ErrSystemPanic) {
+		t.Fatalf("expected SystemPanicError, got %v", err)
+	}
+	if panicErr.System != "integrate" || panicErr.WorkGroup != "physics" || panicErr.Tick != 0 || panicErr.Value != "system exploded" {
+		t.Fatalf("unexpected panic details: %+v", panicErr)
+	}
+	if !strings.Contains(string(panicErr.Stack), "panickingSystem") {
+		t.Fatalf("expected stack trace to name the panicking code")
+	}
+	if !created.IsZero() || world.Registry().Count() != 0 {
+		t.Fatalf("commands deferred before the panic must be rolled back")
+	}
+	if len(observer.summaries) != 1 || !errors.Is(observer.summaries[0].Error, ecs.ErrSystemPanic) {
+		t.Fatalf("expected summary to carry the panic error: %+v", observer.summaries)
+	}
+}
+
+func TestSchedulerPanicHonorsErrorPolicies(t *testing.T) {
+	world := ecs.NewWorld()
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	scheduler.Builder().WithAsyncWorkers(1)
+
+	var retried, skipped ecs.EntityID
+	order := make([]string, 0)
+	groups := []ecs.WorkGroupConfig{
+		{ID: "retry", ErrorPolicy: ecs.ErrorPolicyRetry, Systems: []ecs.System{panickingSystem("flaky", 1, &retried)}},
+		{ID: "continue", ErrorPolicy: ecs.ErrorPolicyContinue, Systems: []ecs.System{panickingSystem("broken", 10, &skipped)}},
+		{ID: "async", Mode: ecs.WorkGroupModeAsync, ErrorPolicy: ecs.ErrorPolicyContinue, Systems: []ecs.System{&testSystem{
+			name: "async-panic",
+			desc: ecs.SystemDescriptor{AsyncAllowed: true},
+			deferCmd: func(ecs.ExecutionContext) {
+				panic(errors.New("async exploded"))
+			},
+		}}},
+		{ID: "after", Systems: []ecs.System{&testSystem{name: "after", executed: &order}}},
+	}
+	for _, cfg := range groups {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if retried.IsZero() || !world.Registry().IsAlive(retried) {
+		t.Fatalf("expected retry after panic to apply its command")
+	}
+	if !skipped.IsZero() {
+		t.Fatalf("expected continue policy to drop the panicking system's commands")
+	}
+	if len(order) != 1 {
+		t.Fatalf("expected later groups to keep running, got %v", order)
+	}
+}
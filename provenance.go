@@ -0,0 +1,114 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// CommandProvenance records where a deferred command came from. Caller holds
+// the "file:line" of the ExecutionContext.Defer call in builds tagged
+// ecsdebug and is empty otherwise.
+type CommandProvenance struct {
+	System    string
+	WorkGroup WorkGroupID
+	Tick      uint64
+	Caller    string
+}
+
+func (p CommandProvenance) String() string {
+	out := fmt.Sprintf("system %s in work group %s at tick %d", p.System, p.WorkGroup, p.Tick)
+	if p.Caller != "" {
+		out += " (" + p.Caller + ")"
+	}
+	return out
+}
+
+// CommandObserver is notified after each command deferred by a system has
+// been applied; err is the command's own result. Under ApplyTransactional the
+// notification waits until the batch settles, and commands undone by a
+// rollback report ErrCommandsRolledBack.
+type CommandObserver interface {
+	CommandApplied(cmd Command, provenance CommandProvenance, err error)
+}
+
+// ProvenanceError wraps the failure of a command deferred by a system.
+type ProvenanceError struct {
+	Provenance CommandProvenance
+	Err        error
+}
+
+func (e *ProvenanceError) Error() string {
+	return fmt.Sprintf("%v (deferred by %s)", e.Err, e.Provenance)
+}
+
+func (e *ProvenanceError) Unwrap() error { return e.Err }
+
+// ProvenanceOf reports the provenance attached to a command deferred through
+// an ExecutionContext.
+func ProvenanceOf(cmd Command) (CommandProvenance, bool) {
+	if traced, ok := cmd.(*tracedCommand); ok {
+		return traced.provenance, true
+	}
+	return CommandProvenance{}, false
+}
+
+// CommandProvenanceFromError extracts the provenance of the failing command
+// from an apply error.
+func CommandProvenanceFromError(err error) (CommandProvenance, bool) {
+	var provErr *ProvenanceError
+	if errors.As(err, &provErr) {
+		return provErr.Provenance, true
+	}
+	return CommandProvenance{}, false
+}
+
+// tracedCommand is the wrapper ExecutionContext.Defer pushes in place of the
+// bare command.
+type tracedCommand struct {
+	cmd        Command
+	provenance CommandProvenance
+	observer   CommandObserver
+}
+
+func (c *tracedCommand) Apply(world *World) error {
+	err := c.cmd.Apply(world)
+	if c.observer != nil && world.journal != nil {
+		world.journal.observe(func(rollback error) {
+			if err == nil {
+				err = rollback
+			}
+			c.observer.CommandApplied(c.cmd, c.provenance, err)
+		})
+	} else if c.observer != nil {
+		c.observer.CommandApplied(c.cmd, c.provenance, err)
+	}
+	if err != nil {
+		return &ProvenanceError{Provenance: c.provenance, Err: err}
+	}
+	return nil
+}
+
+var _ Command = (*tracedCommand)(nil)
+
+// unwrapCommand returns the command a system deferred, without provenance.
+func unwrapCommand(cmd Command) Command {
+	if traced, ok := cmd.(*tracedCommand); ok {
+		return traced.cmd
+	}
+	return cmd
+}
+
+// callerLocation returns "file:line" skip frames above its caller when
+// caller capture is compiled in.
+func callerLocation(skip int) string {
+	if !captureCallers {
+		return ""
+	}
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	return file + ":" + strconv.Itoa(line)
+}
@@ -0,0 +1,7 @@
+//go:build ecsdebug
+
+package ecs
+
+// captureCallers records the caller of ExecutionContext.Defer in command
+// provenance. It costs a runtime.Caller per deferred command.
+const captureCallers = true
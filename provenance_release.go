@@ -0,0 +1,6 @@
+//go:build !ecsdebug
+
+package ecs
+
+// captureCallers is enabled by the ecsdebug build tag.
+const captureCallers = false
@@ -0,0 +1,116 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+type recordingCommandObserver struct {
+	mu      sync.Mutex
+	applied []ecs.CommandProvenance
+	errs    []error
+	failed  int
+}
+
+func (o *recordingCommandObserver) CommandApplied(_ ecs.Command, provenance ecs.CommandProvenance, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.applied = append(o.applied, provenance)
+	o.errs = append(o.errs, err)
+	if err != nil {
+		o.failed++
+	}
+}
+
+func TestApplyErrorsCarryProvenance(t *testing.T) {
+	world := ecs.NewWorld()
+	stale := world.Registry().Create()
+	world.Registry().Destroy(stale)
+
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	observer := &recordingCommandObserver{}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{CommandObserver: observer})
+
+	spawner := &testSystem{name: "spawner", deferCmd: func(ctx ecs.ExecutionContext) {
+		ctx.Defer(ecs.NewCreateEntityCommand(nil))
+	}}
+	reaper := &testSystem{name: "reaper", deferCmd: func(ctx ecs.ExecutionContext) {
+		if ctx.TickIndex() == 1 {
+			ctx.Defer(ecs.NewDestroyEntityCommand(stale))
+		}
+	}}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "combat", Systems: []ecs.System{spawner, reaper}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("first tick: %v", err)
+	}
+	err = scheduler.Tick(context.Background(), time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected stale destroy to fail")
+	}
+	provenance, ok := ecs.CommandProvenanceFromError(err)
+	if !ok {
+		t.Fatalf("expected provenance in %v", err)
+	}
+	if provenance.System != "reaper" || provenance.WorkGroup != "combat" || provenance.Tick != 1 {
+		t.Fatalf("unexpected provenance %+v", provenance)
+	}
+	if !strings.Contains(err.Error(), "system reaper in work group combat at tick 1") {
+		t.Fatalf("expected error to name the origin, got %q", err)
+	}
+	if provenance.Caller != "" && !strings.Contains(provenance.Caller, "provenance_test.go") {
+		t.Fatalf("expected caller inside the system, got %q", provenance.Caller)
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.applied) != 3 || observer.failed != 1 {
+		t.Fatalf("expected three observed commands with one failure, got %d/%d", len(observer.applied), observer.failed)
+	}
+	if observer.applied[0].System != "spawner" || observer.applied[0].Tick != 0 {
+		t.Fatalf("unexpected first provenance %+v", observer.applied[0])
+	}
+}
+
+func TestCommandObserverReportsRolledBackCommands(t *testing.T) {
+	world := ecs.NewWorld(ecs.WithApplyMode(ecs.ApplyTransactional))
+	stale := world.Registry().Create()
+	world.Registry().Destroy(stale)
+
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	observer := &recordingCommandObserver{}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{CommandObserver: observer})
+	system := &testSystem{name: "spawner", deferCmd: func(ctx ecs.ExecutionContext) {
+		ctx.Defer(ecs.NewCreateEntityCommand(nil))
+		ctx.Defer(ecs.NewDestroyEntityCommand(stale))
+	}}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "combat", Systems: []ecs.System{system}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); !errors.Is(err, ecs.ErrCommandsRolledBack) {
+		t.Fatalf("expected rollback, got %v", err)
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.errs) != 2 || !errors.Is(observer.errs[0], ecs.ErrCommandsRolledBack) {
+		t.Fatalf("expected the undone create to report ErrCommandsRolledBack, got %v", observer.errs)
+	}
+	if observer.errs[1] == nil || errors.Is(observer.errs[1], ecs.ErrCommandsRolledBack) {
+		t.Fatalf("expected the failing destroy to report its own error, got %v", observer.errs[1])
+	}
+}
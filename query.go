@@ -0,0 +1,322 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// QueryConfig declares the component filters evaluated by a Query.
+type QueryConfig struct {
+	// With lists components an entity must hold to be yielded.
+	With []ComponentType
+	// Without lists components that exclude an entity when present.
+	Without []ComponentType
+	// Optional lists components fetched when present without filtering entities.
+	Optional []ComponentType
+	// Writes marks components from With or Optional the caller intends to mutate
+	// through deferred commands. It only affects access reporting.
+	Writes []ComponentType
+	// Added and Changed restrict EachSince and EachChanged to entities whose
+	// listed components were added, or set, since a tick. Both must name
+	// components from With; Each ignores them.
+	Added   []ComponentType
+	Changed []ComponentType
+}
+
+// Query joins component views so systems can iterate entities holding a set of
+// components without hand-rolling lookups against each view.
+type Query struct {
+	with     []ComponentType
+	without  []ComponentType
+	optional []ComponentType
+	writes   []ComponentType
+	added    []ComponentType
+	changed  []ComponentType
+	columns  map[ComponentType]int
+}
+
+// NewQuery validates the configuration and constructs a reusable query.
+func NewQuery(cfg QueryConfig) (*Query, error) {
+	if len(cfg.With) == 0 {
+		return nil, ErrQueryRequiresComponent
+	}
+
+	q := &Query{columns: make(map[ComponentType]int)}
+	seen := make(map[ComponentType]string)
+	claim := func(t ComponentType, filter string) error {
+		if prev, ok := seen[t]; ok {
+			return fmt.Errorf("%w: component %s listed in both %s and %s", ErrQueryConflictingFilter, t, prev, filter)
+		}
+		seen[t] = filter
+		return nil
+	}
+
+	for _, t := range cfg.With {
+		if err := claim(t, "With"); err != nil {
+			return nil, err
+		}
+		q.columns[t] = len(q.columns)
+		q.with = append(q.with, t)
+	}
+	for _, t := range cfg.Optional {
+		if err := claim(t, "Optional"); err != nil {
+			return nil, err
+		}
+		q.columns[t] = len(q.columns)
+		q.optional = append(q.optional, t)
+	}
+	for _, t := range cfg.Without {
+		if err := claim(t, "Without"); err != nil {
+			return nil, err
+		}
+		q.without = append(q.without, t)
+	}
+
+	written := make(map[ComponentType]struct{}, len(cfg.Writes))
+	for _, t := range cfg.Writes {
+		if _, ok := q.columns[t]; !ok {
+			return nil, fmt.Errorf("%w: write to %s requires it in With or Optional", ErrQueryConflictingFilter, t)
+		}
+		if _, dup := written[t]; dup {
+			continue
+		}
+		written[t] = struct{}{}
+		q.writes = append(q.writes, t)
+	}
+
+	required := make(map[ComponentType]struct{}, len(q.with))
+	for _, t := range q.with {
+		required[t] = struct{}{}
+	}
+	for _, t := range cfg.Added {
+		if _, ok := required[t]; !ok {
+			return nil, fmt.Errorf("%w: Added filter on %s requires it in With", ErrQueryConflictingFilter, t)
+		}
+		q.added = append(q.added, t)
+	}
+	for _, t := range cfg.Changed {
+		if _, ok := required[t]; !ok {
+			return nil, fmt.Errorf("%w: Changed filter on %s requires it in With", ErrQueryConflictingFilter, t)
+		}
+		q.changed = append(q.changed, t)
+	}
+	return q, nil
+}
+
+// MustQuery is like NewQuery but panics on invalid configuration. It is intended
+// for package-level query declarations.
+func MustQuery(cfg QueryConfig) *Query {
+	q, err := NewQuery(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Reads returns the components the query fetches without declaring a write.
+func (q *Query) Reads() []ComponentType {
+	written := make(map[ComponentType]struct{}, len(q.writes))
+	for _, t := range q.writes {
+		written[t] = struct{}{}
+	}
+	out := make([]ComponentType, 0, len(q.columns))
+	for _, t := range append(append([]ComponentType(nil), q.with...), q.optional...) {
+		if _, ok := written[t]; ok {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// Writes returns the components the query declared as mutated.
+func (q *Query) Writes() []ComponentType {
+	return append([]ComponentType(nil), q.writes...)
+}
+
+// ValidateAccess reports whether the query's reads and writes are covered by the
+// descriptor, matching the access metadata the scheduler validates.
+func (q *Query) ValidateAccess(desc SystemDescriptor) error {
+	reads := make(map[ComponentType]struct{}, len(desc.Reads)+len(desc.Writes))
+	writes := make(map[ComponentType]struct{}, len(desc.Writes))
+	for _, t := range desc.Reads {
+		reads[t] = struct{}{}
+	}
+	for _, t := range desc.Writes {
+		reads[t] = struct{}{}
+		writes[t] = struct{}{}
+	}
+
+	var errs []error
+	for _, t := range q.Reads() {
+		if _, ok := reads[t]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %s reads %s", ErrQueryAccessUndeclared, desc.Name, t))
+		}
+	}
+	for _, t := range q.writes {
+		if _, ok := writes[t]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %s writes %s", ErrQueryAccessUndeclared, desc.Name, t))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Each iterates entities matching the query. Iteration is driven by the smallest
+// required view; the row passed to fn is reused and only valid during the call.
+func (q *Query) Each(world *World, fn func(row *QueryRow) bool) error {
+	return q.each(world, nil, fn)
+}
+
+// EachSince is like Each but also applies the Added and Changed filters,
+// yielding only entities whose filtered components were added or set after
+// systems of the given tick ran. Values written directly to stores are never
+// considered changed.
+func (q *Query) EachSince(world *World, tick uint64, fn func(row *QueryRow) bool) error {
+	if len(q.added) == 0 && len(q.changed) == 0 {
+		return q.each(world, nil, fn)
+	}
+	return q.each(world, func(id EntityID) bool {
+		for _, t := range q.added {
+			ticks, ok := world.ComponentTicks(t, id)
+			if !ok || ticks.Added <= tick {
+				return false
+			}
+		}
+		for _, t := range q.changed {
+			ticks, ok := world.ComponentTicks(t, id)
+			if !ok || ticks.Changed <= tick {
+				return false
+			}
+		}
+		return true
+	}, fn)
+}
+
+// EachChanged applies the Added and Changed filters relative to the running
+// system's previous run. On a system's first run every matching entity is
+// yielded.
+func (q *Query) EachChanged(exec ExecutionContext, fn func(row *QueryRow) bool) error {
+	since, ok := exec.LastRunTick()
+	if !ok {
+		return q.each(exec.World(), nil, fn)
+	}
+	return q.EachSince(exec.World(), since, fn)
+}
+
+func (q *Query) each(world *World, keep func(EntityID) bool, fn func(row *QueryRow) bool) error {
+	if world == nil || fn == nil {
+		return nil
+	}
+
+	required := make([]ComponentView, len(q.with))
+	driver := 0
+	for i, t := range q.with {
+		view, err := world.ViewComponent(t)
+		if err != nil {
+			return fmt.Errorf("ecs: query component %s: %w", t, err)
+		}
+		required[i] = view
+		if view.Len() < required[driver].Len() {
+			driver = i
+		}
+	}
+	excluded := q.optionalViews(world, q.without)
+	optional := q.optionalViews(world, q.optional)
+
+	row := &QueryRow{
+		columns: q.columns,
+		values:  make([]any, len(q.columns)),
+		present: make([]bool, len(q.columns)),
+	}
+	base := len(q.with)
+
+	required[driver].Iterate(func(id EntityID, value any) bool {
+		if keep != nil && !keep(id) {
+			return true
+		}
+		for _, view := range excluded {
+			if view != nil && view.Has(id) {
+				return true
+			}
+		}
+		for i, view := range required {
+			if i == driver {
+				row.values[i], row.present[i] = value, true
+				continue
+			}
+			v, ok := view.Get(id)
+			if !ok {
+				return true
+			}
+			row.values[i], row.present[i] = v, true
+		}
+		for i, view := range optional {
+			row.values[base+i], row.present[base+i] = nil, false
+			if view == nil {
+				continue
+			}
+			row.values[base+i], row.present[base+i] = view.Get(id)
+		}
+		row.entity = id
+		return fn(row)
+	})
+	return nil
+}
+
+// optionalViews resolves views whose absence is tolerated; unregistered
+// components resolve to nil entries.
+func (q *Query) optionalViews(world *World, types []ComponentType) []ComponentView {
+	if len(types) == 0 {
+		return nil
+	}
+	views := make([]ComponentView, len(types))
+	for i, t := range types {
+		if view, err := world.ViewComponent(t); err == nil {
+			views[i] = view
+		}
+	}
+	return views
+}
+
+// QueryRow exposes the components fetched for a single matching entity.
+type QueryRow struct {
+	entity  EntityID
+	columns map[ComponentType]int
+	values  []any
+	present []bool
+}
+
+// Entity returns the entity the row describes.
+func (r *QueryRow) Entity() EntityID {
+	return r.entity
+}
+
+// Get returns the raw component value fetched for the entity.
+func (r *QueryRow) Get(t ComponentType) (any, bool) {
+	idx, ok := r.columns[t]
+	if !ok || !r.present[idx] {
+		return nil, false
+	}
+	return r.values[idx], true
+}
+
+// Has reports whether the row carries a value for the component.
+func (r *QueryRow) Has(t ComponentType) bool {
+	_, ok := r.Get(t)
+	return ok
+}
+
+// QueryValue returns the row's component value asserted to T. It reports false
+// when the component is absent or holds a different Go type.
+func QueryValue[T any](row *QueryRow, t ComponentType) (T, bool) {
+	var zero T
+	raw, ok := row.Get(t)
+	if !ok {
+		return zero, false
+	}
+	value, ok := raw.(T)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
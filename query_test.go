@@ -0,0 +1,140 @@
+package ecs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+type queryPosition struct{ X, Y float64 }
+
+type queryVelocity struct{ DX, DY float64 }
+
+func newQueryWorld(t *testing.T, types ...ecs.ComponentType) *ecs.World {
+	t.Helper()
+	world := ecs.NewWorld()
+	for _, typ := range types {
+		if err := world.RegisterComponent(typ, ecsstorage.NewDenseStrategy()); err != nil {
+			t.Fatalf("register %s: %v", typ, err)
+		}
+	}
+	return world
+}
+
+func TestQueryFiltersWithWithoutOptional(t *testing.T) {
+	world := newQueryWorld(t, "Position", "Velocity", "Frozen", "Tag")
+
+	moving := world.Registry().Create()
+	frozen := world.Registry().Create()
+	tagged := world.Registry().Create()
+	static := world.Registry().Create()
+
+	cmds := []ecs.Command{
+		ecs.NewAddComponentCommand(moving, "Position", queryPosition{X: 1}),
+		ecs.NewAddComponentCommand(moving, "Velocity", queryVelocity{DX: 2}),
+		ecs.NewAddComponentCommand(frozen, "Position", queryPosition{}),
+		ecs.NewAddComponentCommand(frozen, "Velocity", queryVelocity{}),
+		ecs.NewAddComponentCommand(frozen, "Frozen", true),
+		ecs.NewAddComponentCommand(tagged, "Position", queryPosition{X: 3}),
+		ecs.NewAddComponentCommand(tagged, "Velocity", queryVelocity{DX: 4}),
+		ecs.NewAddComponentCommand(tagged, "Tag", "boss"),
+		ecs.NewAddComponentCommand(static, "Position", queryPosition{}),
+	}
+	if err := world.ApplyCommands(cmds); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	q, err := ecs.NewQuery(ecs.QueryConfig{
+		With:     []ecs.ComponentType{"Position", "Velocity"},
+		Without:  []ecs.ComponentType{"Frozen"},
+		Optional: []ecs.ComponentType{"Tag"},
+	})
+	if err != nil {
+		t.Fatalf("new query: %v", err)
+	}
+
+	seen := make(map[ecs.EntityID]string)
+	err = q.Each(world, func(row *ecs.QueryRow) bool {
+		pos, ok := ecs.QueryValue[queryPosition](row, "Position")
+		if !ok {
+			t.Fatalf("expected position for %v", row.Entity())
+		}
+		vel, ok := ecs.QueryValue[queryVelocity](row, "Velocity")
+		if !ok {
+			t.Fatalf("expected velocity for %v", row.Entity())
+		}
+		if vel.DX != pos.X+1 {
+			t.Fatalf("mismatched join for %v: %+v %+v", row.Entity(), pos, vel)
+		}
+		tag, _ := ecs.QueryValue[string](row, "Tag")
+		seen[row.Entity()] = tag
+		return true
+	})
+	if err != nil {
+		t.Fatalf("each: %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 matches, got %v", seen)
+	}
+	if tag, ok := seen[moving]; !ok || tag != "" {
+		t.Fatalf("expected untagged moving entity, got %q (ok=%v)", tag, ok)
+	}
+	if tag := seen[tagged]; tag != "boss" {
+		t.Fatalf("expected optional tag, got %q", tag)
+	}
+}
+
+func TestQueryRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ecs.QueryConfig
+		want error
+	}{
+		{name: "empty", cfg: ecs.QueryConfig{}, want: ecs.ErrQueryRequiresComponent},
+		{name: "with and without", cfg: ecs.QueryConfig{With: []ecs.ComponentType{"A"}, Without: []ecs.ComponentType{"A"}}, want: ecs.ErrQueryConflictingFilter},
+		{name: "write outside fetch", cfg: ecs.QueryConfig{With: []ecs.ComponentType{"A"}, Writes: []ecs.ComponentType{"B"}}, want: ecs.ErrQueryConflictingFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ecs.NewQuery(tt.cfg); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestQueryValidateAccess(t *testing.T) {
+	q := ecs.MustQuery(ecs.QueryConfig{
+		With:     []ecs.ComponentType{"Position", "Velocity"},
+		Optional: []ecs.ComponentType{"Tag"},
+		Writes:   []ecs.ComponentType{"Position"},
+	})
+
+	ok := ecs.SystemDescriptor{
+		Name:   "movement",
+		Reads:  []ecs.ComponentType{"Velocity", "Tag"},
+		Writes: []ecs.ComponentType{"Position"},
+	}
+	if err := q.ValidateAccess(ok); err != nil {
+		t.Fatalf("expected access to validate: %v", err)
+	}
+
+	missingWrite := ecs.SystemDescriptor{
+		Name:  "movement",
+		Reads: []ecs.ComponentType{"Position", "Velocity", "Tag"},
+	}
+	if err := q.ValidateAccess(missingWrite); !errors.Is(err, ecs.ErrQueryAccessUndeclared) {
+		t.Fatalf("expected ErrQueryAccessUndeclared, got %v", err)
+	}
+}
+
+func TestQueryUnregisteredRequiredComponent(t *testing.T) {
+	world := newQueryWorld(t, "Position")
+	q := ecs.MustQuery(ecs.QueryConfig{With: []ecs.ComponentType{"Position", "Missing"}})
+	if err := q.Each(world, func(*ecs.QueryRow) bool { return true }); !errors.Is(err, ecs.ErrComponentNotRegistered) {
+		t.Fatalf("expected ErrComponentNotRegistered, got %v", err)
+	}
+}
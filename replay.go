@@ -0,0 +1,452 @@
+package ecs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ReplayFormatVersion is the layout version written by Recorder.
+const ReplayFormatVersion uint16 = 1
+
+var replayMagic = [8]byte{'E', 'C', 'S', 'R', 'E', 'P', 'L', 0}
+
+// resourceInputKind is the built-in input recorded by Recorder.SetResource.
+const resourceInputKind = "ecs.resource"
+
+// StateHash returns an FNV-1a hash of the world's snapshot, covering the
+// entity registry, hierarchy, components and opted-in resources. It has the
+// same codec requirements as Snapshot.
+func (w *World) StateHash() (uint64, error) {
+	h := fnv.New64a()
+	if err := w.Snapshot(h); err != nil {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
+// InputHandler applies an externally injected input, such as a player action
+// decoded from data, to the world between ticks. Recorder and Replayer must
+// be given the same handlers.
+type InputHandler func(world *World, data []byte) error
+
+// Input is one injected input of a recorded tick.
+type Input struct {
+	Kind string
+	Data []byte
+}
+
+// ReplayFrame is one recorded tick.
+type ReplayFrame struct {
+	Tick   uint64
+	DT     time.Duration
+	Inputs []Input
+	// Failed records whether Tick returned an error; a replay must fail the
+	// same tick.
+	Failed bool
+	Hash   uint64
+}
+
+// ReplayDivergence reports a replayed tick that does not match its recording.
+type ReplayDivergence struct {
+	Tick   uint64
+	Reason string
+}
+
+func (e *ReplayDivergence) Error() string {
+	return fmt.Sprintf("%v at tick %d: %s", ErrReplayDiverged, e.Tick, e.Reason)
+}
+
+func (e *ReplayDivergence) Unwrap() error { return ErrReplayDiverged }
+
+// replayScheduler is implemented by schedulers returned by NewScheduler.
+type replayScheduler interface {
+	Scheduler
+	TickIndex() uint64
+	replayState() (uint64, map[WorkGroupID][]uint64)
+	seek(tick uint64, runs map[WorkGroupID][]uint64) error
+}
+
+// Recorder drives a scheduler and records everything needed to reproduce the
+// session: the initial world snapshot with its change ticks and each system's
+// last-run tick, each tick's index and dt, the inputs injected before it and
+// the resulting state hash.
+//
+// Systems must be deterministic for a replay to match: no wall-clock time,
+// unseeded randomness or SpanTicks groups. Pending events and scheduler state
+// such as retry backoff are not captured, so start recording between ticks
+// while both are quiescent.
+type Recorder struct {
+	scheduler replayScheduler
+	world     *World
+	out       io.Writer
+	inputs    map[string]InputHandler
+
+	mu      sync.Mutex
+	pending []Input
+	broken  error // set once a tick ran without its frame being written
+}
+
+// NewRecorder writes the replay header, including a snapshot of world, to
+// out. All later world changes must go through Recorder.Tick, Inject or
+// SetResource.
+func NewRecorder(scheduler Scheduler, world *World, out io.Writer, inputs map[string]InputHandler) (*Recorder, error) {
+	sched, ok := scheduler.(replayScheduler)
+	if !ok {
+		return nil, fmt.Errorf("ecs: recorder requires a scheduler from NewScheduler")
+	}
+	var snapshot bytes.Buffer
+	if err := world.Snapshot(&snapshot); err != nil {
+		return nil, fmt.Errorf("ecs: record initial snapshot: %w", err)
+	}
+	tick, runs := sched.replayState()
+	enc := &snapshotEncoder{}
+	enc.buf.Write(replayMagic[:])
+	enc.u16(ReplayFormatVersion)
+	enc.u64(tick)
+	enc.bytes(snapshot.Bytes())
+	encodeChangeTicks(enc, world.changes.all())
+	encodeSystemRuns(enc, runs)
+	if _, err := out.Write(enc.buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return &Recorder{scheduler: sched, world: world, out: out, inputs: inputs}, nil
+}
+
+// Inject queues an input for the next Tick. It is safe to call concurrently.
+func (r *Recorder) Inject(kind string, data []byte) error {
+	if _, ok := r.inputs[kind]; !ok {
+		return fmt.Errorf("ecs: no input handler for %q", kind)
+	}
+	r.mu.Lock()
+	r.pending = append(r.pending, Input{Kind: kind, Data: append([]byte(nil), data...)})
+	r.mu.Unlock()
+	return nil
+}
+
+// SetResource queues a resource write for the next Tick. The resource needs a
+// codec registered with World.RegisterResourceCodec.
+func (r *Recorder) SetResource(name string, value any) error {
+	r.world.codecs.mu.RLock()
+	codec := r.world.codecs.resources[name]
+	r.world.codecs.mu.RUnlock()
+	if codec == nil {
+		return fmt.Errorf("%w: resource %s", ErrCodecNotRegistered, name)
+	}
+	data, err := codec.Encode(value)
+	if err != nil {
+		return fmt.Errorf("ecs: record resource %s: %w", name, err)
+	}
+	enc := &snapshotEncoder{}
+	enc.str(name)
+	enc.u32(codec.Version())
+	enc.bytes(data)
+	r.mu.Lock()
+	r.pending = append(r.pending, Input{Kind: resourceInputKind, Data: enc.buf.Bytes()})
+	r.mu.Unlock()
+	return nil
+}
+
+// Tick applies queued inputs, runs one scheduler tick and records the frame.
+// A failed tick is recorded too, so the failure can be replayed. If the frame
+// cannot be hashed or written the recording is incomplete, and every later
+// Tick fails without running.
+func (r *Recorder) Tick(ctx context.Context, dt time.Duration) error {
+	r.mu.Lock()
+	if r.broken != nil {
+		r.mu.Unlock()
+		return fmt.Errorf("ecs: recording stopped: %w", r.broken)
+	}
+	inputs := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	frame := ReplayFrame{Tick: r.scheduler.TickIndex(), DT: dt, Inputs: inputs}
+	tickErr := applyInputs(r.world, r.inputs, inputs)
+	if tickErr == nil {
+		tickErr = r.scheduler.Tick(ctx, dt)
+	}
+	frame.Failed = tickErr != nil
+	hash, err := r.world.StateHash()
+	if err != nil {
+		return errors.Join(tickErr, r.stop(fmt.Errorf("ecs: hash tick %d: %w", frame.Tick, err)))
+	}
+	frame.Hash = hash
+
+	enc := &snapshotEncoder{}
+	encodeFrame(enc, frame)
+	if _, err := r.out.Write(enc.buf.Bytes()); err != nil {
+		return errors.Join(tickErr, r.stop(fmt.Errorf("ecs: write tick %d: %w", frame.Tick, err)))
+	}
+	return tickErr
+}
+
+// stop marks the recording incomplete after a tick ran without its frame.
+func (r *Recorder) stop(err error) error {
+	r.mu.Lock()
+	r.broken = err
+	r.mu.Unlock()
+	return err
+}
+
+// Replayer restores a recording's initial world and re-drives the scheduler
+// with the recorded stream, checking the state hash after every tick. The
+// scheduler must have the same work groups registered as during recording.
+type Replayer struct {
+	scheduler replayScheduler
+	world     *World
+	inputs    map[string]InputHandler
+	dec       *snapshotDecoder
+}
+
+// NewReplayer reads the replay header from in, restores world from it and
+// moves the scheduler to the recorded start tick.
+func NewReplayer(scheduler Scheduler, world *World, in io.Reader, inputs map[string]InputHandler) (*Replayer, error) {
+	sched, ok := scheduler.(replayScheduler)
+	if !ok {
+		return nil, fmt.Errorf("ecs: replayer requires a scheduler from NewScheduler")
+	}
+	dec := &snapshotDecoder{r: bufio.NewReader(in)}
+	var magic [8]byte
+	dec.read(magic[:])
+	if dec.err != nil || magic != replayMagic {
+		return nil, fmt.Errorf("%w: missing replay header", ErrReplayInvalid)
+	}
+	if version := dec.u16(); dec.err == nil && (version == 0 || version > ReplayFormatVersion) {
+		return nil, fmt.Errorf("%w: replay format %d, supported up to %d", ErrReplayInvalid, version, ReplayFormatVersion)
+	}
+	start := dec.u64()
+	snapshot := dec.bytes()
+	changes := decodeChangeTicks(dec)
+	runs := decodeSystemRuns(dec)
+	if dec.err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReplayInvalid, dec.err)
+	}
+	if err := world.Restore(bytes.NewReader(snapshot)); err != nil {
+		return nil, fmt.Errorf("ecs: replay initial snapshot: %w", err)
+	}
+	if err := sched.seek(start, runs); err != nil {
+		return nil, err
+	}
+	// Restore stamps every component as added now; put back the recorded
+	// ticks so change detection sees what it saw during recording.
+	world.changes.replace(changes)
+	return &Replayer{scheduler: sched, world: world, inputs: inputs, dec: dec}, nil
+}
+
+// Step replays the next recorded tick. It returns io.EOF once the recording
+// is exhausted and a *ReplayDivergence when the tick does not match.
+func (r *Replayer) Step(ctx context.Context) (ReplayFrame, error) {
+	if _, err := r.dec.r.Peek(1); err == io.EOF {
+		return ReplayFrame{}, io.EOF
+	}
+	frame := decodeFrame(r.dec)
+	if r.dec.err != nil {
+		return frame, fmt.Errorf("%w: %w", ErrReplayInvalid, r.dec.err)
+	}
+	if tick := r.scheduler.TickIndex(); tick != frame.Tick {
+		return frame, &ReplayDivergence{Tick: frame.Tick, Reason: fmt.Sprintf("scheduler is at tick %d", tick)}
+	}
+
+	tickErr := applyInputs(r.world, r.inputs, frame.Inputs)
+	if tickErr == nil {
+		tickErr = r.scheduler.Tick(ctx, frame.DT)
+	}
+	if failed := tickErr != nil; failed != frame.Failed {
+		return frame, &ReplayDivergence{Tick: frame.Tick, Reason: fmt.Sprintf("tick error %v, recording failed=%t", tickErr, frame.Failed)}
+	}
+	hash, err := r.world.StateHash()
+	if err != nil {
+		return frame, err
+	}
+	if hash != frame.Hash {
+		return frame, &ReplayDivergence{Tick: frame.Tick, Reason: fmt.Sprintf("state hash %016x, recorded %016x", hash, frame.Hash)}
+	}
+	return frame, nil
+}
+
+// Run replays every remaining tick and returns how many matched.
+func (r *Replayer) Run(ctx context.Context) (int, error) {
+	for ticks := 0; ; ticks++ {
+		if _, err := r.Step(ctx); err != nil {
+			if err == io.EOF {
+				return ticks, nil
+			}
+			return ticks, err
+		}
+	}
+}
+
+// replayState returns the next tick index and a copy of each group's
+// per-system last-run ticks.
+func (s *basicScheduler) replayState() (uint64, map[WorkGroupID][]uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	runs := make(map[WorkGroupID][]uint64, len(s.groupStates))
+	for id, group := range s.groupStates {
+		runs[id] = append([]uint64(nil), group.systemRuns...)
+	}
+	return s.tickIndex, runs
+}
+
+// seek moves an idle scheduler to tick and restores each system's last-run
+// tick so a replay can continue a recording taken mid-session.
+func (s *basicScheduler) seek(tick uint64, runs map[WorkGroupID][]uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ticking {
+		return fmt.Errorf("ecs: cannot seek a ticking scheduler")
+	}
+	if len(runs) != len(s.groupStates) {
+		return fmt.Errorf("%w: recorded %d work groups, scheduler has %d", ErrReplayInvalid, len(runs), len(s.groupStates))
+	}
+	for id, systemRuns := range runs {
+		group, ok := s.groupStates[id]
+		if !ok || len(group.systemRuns) != len(systemRuns) {
+			return fmt.Errorf("%w: work group %s does not match the recording", ErrReplayInvalid, id)
+		}
+	}
+	for id, systemRuns := range runs {
+		copy(s.groupStates[id].systemRuns, systemRuns)
+	}
+	s.tickIndex = tick
+	s.world.changes.setTick(tick)
+	return nil
+}
+
+func applyInputs(world *World, handlers map[string]InputHandler, inputs []Input) error {
+	for _, input := range inputs {
+		var err error
+		if input.Kind == resourceInputKind {
+			err = applyResourceInput(world, input.Data)
+		} else if handler, ok := handlers[input.Kind]; ok {
+			err = handler(world, input.Data)
+		} else {
+			err = fmt.Errorf("ecs: no input handler for %q", input.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("ecs: apply input %s: %w", input.Kind, err)
+		}
+	}
+	return nil
+}
+
+func applyResourceInput(world *World, data []byte) error {
+	dec := &snapshotDecoder{r: bufio.NewReader(bytes.NewReader(data))}
+	name := dec.str()
+	version := dec.u32()
+	payload := dec.bytes()
+	if dec.err != nil {
+		return dec.err
+	}
+	world.codecs.mu.RLock()
+	codec := world.codecs.resources[name]
+	world.codecs.mu.RUnlock()
+	if codec == nil {
+		return fmt.Errorf("%w: resource %s", ErrCodecNotRegistered, name)
+	}
+	value, err := codec.Decode(version, payload)
+	if err != nil {
+		return err
+	}
+	world.resources.Set(name, value)
+	return nil
+}
+
+// encodeChangeTicks writes change ticks sorted by component type and entity
+// index so equal worlds produce equal headers.
+func encodeChangeTicks(enc *snapshotEncoder, entries map[ComponentType]map[uint32]trackedTicks) {
+	types := slices.Sorted(maps.Keys(entries))
+	enc.u32(uint32(len(types)))
+	for _, t := range types {
+		byIndex := entries[t]
+		enc.str(string(t))
+		enc.u32(uint32(len(byIndex)))
+		for _, index := range slices.Sorted(maps.Keys(byIndex)) {
+			entry := byIndex[index]
+			enc.entity(entry.id)
+			enc.u64(entry.ticks.Added)
+			enc.u64(entry.ticks.Changed)
+		}
+	}
+}
+
+func decodeChangeTicks(dec *snapshotDecoder) map[ComponentType]map[uint32]trackedTicks {
+	entries := make(map[ComponentType]map[uint32]trackedTicks)
+	types := dec.length()
+	for i := 0; i < types && dec.err == nil; i++ {
+		t := ComponentType(dec.str())
+		n := dec.length()
+		byIndex := make(map[uint32]trackedTicks)
+		for j := 0; j < n && dec.err == nil; j++ {
+			entry := trackedTicks{id: dec.entity()}
+			entry.ticks.Added = dec.u64()
+			entry.ticks.Changed = dec.u64()
+			byIndex[entry.id.index] = entry
+		}
+		entries[t] = byIndex
+	}
+	return entries
+}
+
+func encodeSystemRuns(enc *snapshotEncoder, runs map[WorkGroupID][]uint64) {
+	ids := slices.Sorted(maps.Keys(runs))
+	enc.u32(uint32(len(ids)))
+	for _, id := range ids {
+		enc.str(string(id))
+		enc.u32(uint32(len(runs[id])))
+		for _, run := range runs[id] {
+			enc.u64(run)
+		}
+	}
+}
+
+func decodeSystemRuns(dec *snapshotDecoder) map[WorkGroupID][]uint64 {
+	runs := make(map[WorkGroupID][]uint64)
+	groups := dec.length()
+	for i := 0; i < groups && dec.err == nil; i++ {
+		id := WorkGroupID(dec.str())
+		n := dec.length()
+		systemRuns := make([]uint64, 0, min(n, 1<<16))
+		for j := 0; j < n && dec.err == nil; j++ {
+			systemRuns = append(systemRuns, dec.u64())
+		}
+		runs[id] = systemRuns
+	}
+	return runs
+}
+
+func encodeFrame(enc *snapshotEncoder, frame ReplayFrame) {
+	enc.u64(frame.Tick)
+	enc.u64(uint64(frame.DT))
+	enc.u32(uint32(len(frame.Inputs)))
+	for _, input := range frame.Inputs {
+		enc.str(input.Kind)
+		enc.bytes(input.Data)
+	}
+	if frame.Failed {
+		enc.u8(1)
+	} else {
+		enc.u8(0)
+	}
+	enc.u64(frame.Hash)
+}
+
+func decodeFrame(dec *snapshotDecoder) ReplayFrame {
+	frame := ReplayFrame{Tick: dec.u64(), DT: time.Duration(dec.u64())}
+	n := dec.length()
+	for i := 0; i < n && dec.err == nil; i++ {
+		frame.Inputs = append(frame.Inputs, Input{Kind: dec.str(), Data: dec.bytes()})
+	}
+	frame.Failed = dec.u8() == 1
+	frame.Hash = dec.u64()
+	return frame
+}
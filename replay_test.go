@@ -0,0 +1,214 @@
+package ecs_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+// driftSystem moves every position by speed*dt plus the "wind" resource.
+type driftSystem struct {
+	position ecs.Component[snapshotPosition]
+	speed    float64
+}
+
+func (s *driftSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: "drift", Writes: []ecs.ComponentType{"Position"}}
+}
+
+func (s *driftSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	wind, _ := exec.World().Resources().Get("wind")
+	step := s.speed * exec.TimeDelta().Seconds()
+	if w, ok := wind.(float64); ok {
+		step += w
+	}
+	err := s.position.Iterate(exec.World(), func(id ecs.EntityID, pos snapshotPosition) bool {
+		exec.Defer(s.position.AddCommand(id, snapshotPosition{X: pos.X + step, Y: pos.Y}))
+		return true
+	})
+	return ecs.SystemResult{Err: err}
+}
+
+// sightingSystem counts, on each entity, how many times its Position was seen
+// as newly added, so replays depend on change ticks and last-run ticks.
+type sightingSystem struct {
+	query *ecs.Query
+	seen  ecs.Component[int]
+}
+
+func (s *sightingSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: "sighting", Reads: []ecs.ComponentType{"Position"}, Writes: []ecs.ComponentType{"Seen"}}
+}
+
+func (s *sightingSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	err := s.query.EachChanged(exec, func(row *ecs.QueryRow) bool {
+		count, _ := s.seen.Get(exec.World(), row.Entity())
+		exec.Defer(s.seen.AddCommand(row.Entity(), count+1))
+		return true
+	})
+	return ecs.SystemResult{Err: err}
+}
+
+func newReplayRig(t *testing.T, speed float64) (*ecs.World, ecs.Scheduler, map[string]ecs.InputHandler) {
+	t.Helper()
+	world := ecs.NewWorld()
+	position, err := ecs.RegisterComponent[snapshotPosition](world, "Position", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := world.RegisterComponentCodec("Position", ecs.GobCodec[snapshotPosition]()); err != nil {
+		t.Fatalf("register codec: %v", err)
+	}
+	seen, err := ecs.RegisterComponent[int](world, "Seen", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := world.RegisterComponentCodec("Seen", ecs.GobCodec[int]()); err != nil {
+		t.Fatalf("register codec: %v", err)
+	}
+	if err := world.RegisterResourceCodec("wind", ecs.GobCodec[float64]()); err != nil {
+		t.Fatalf("register resource codec: %v", err)
+	}
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "physics", Systems: []ecs.System{&driftSystem{position: position, speed: speed}}}); err != nil {
+		t.Fatalf("register group: %v", err)
+	}
+	sighting := &sightingSystem{
+		query: ecs.MustQuery(ecs.QueryConfig{With: []ecs.ComponentType{"Position"}, Added: []ecs.ComponentType{"Position"}}),
+		seen:  seen,
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "sighting", Systems: []ecs.System{sighting}}); err != nil {
+		t.Fatalf("register group: %v", err)
+	}
+	inputs := map[string]ecs.InputHandler{
+		"spawn": func(world *ecs.World, data []byte) error {
+			x := math.Float64frombits(binary.LittleEndian.Uint64(data))
+			spawn, _ := ecs.NewSpawnCommand(ecs.Bundle{position.With(snapshotPosition{X: x})})
+			return world.ApplyCommands([]ecs.Command{spawn})
+		},
+	}
+	return world, scheduler, inputs
+}
+
+func spawnInput(x float64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(x))
+}
+
+func recordSession(t *testing.T, warmup int) []byte {
+	t.Helper()
+	world, scheduler, inputs := newReplayRig(t, 2)
+	ctx := context.Background()
+	if warmup > 0 {
+		// An entity sighted before recording must not be sighted again on
+		// replay.
+		if err := inputs["spawn"](world, spawnInput(0)); err != nil {
+			t.Fatalf("spawn: %v", err)
+		}
+	}
+	if err := scheduler.Run(ctx, warmup, 10*time.Millisecond); err != nil {
+		t.Fatalf("warmup: %v", err)
+	}
+	var recording bytes.Buffer
+	recorder, err := ecs.NewRecorder(scheduler, world, &recording, inputs)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	for tick := range 6 {
+		switch tick {
+		case 1:
+			if err := recorder.Inject("spawn", spawnInput(5)); err != nil {
+				t.Fatalf("inject: %v", err)
+			}
+		case 3:
+			if err := recorder.SetResource("wind", 0.25); err != nil {
+				t.Fatalf("set resource: %v", err)
+			}
+			if err := recorder.Inject("spawn", spawnInput(-1)); err != nil {
+				t.Fatalf("inject: %v", err)
+			}
+		}
+		if err := recorder.Tick(ctx, time.Duration(tick+1)*time.Millisecond); err != nil {
+			t.Fatalf("record tick %d: %v", tick, err)
+		}
+	}
+	if err := recorder.Inject("unknown", nil); err == nil {
+		t.Fatalf("expected unknown input kind to be rejected")
+	}
+	return recording.Bytes()
+}
+
+func TestReplayReproducesRecording(t *testing.T) {
+	for _, warmup := range []int{0, 3} {
+		recording := recordSession(t, warmup)
+		world, scheduler, inputs := newReplayRig(t, 2)
+		replayer, err := ecs.NewReplayer(scheduler, world, bytes.NewReader(recording), inputs)
+		if err != nil {
+			t.Fatalf("new replayer: %v", err)
+		}
+		ticks, err := replayer.Run(context.Background())
+		if err != nil {
+			t.Fatalf("replay after %d warmup ticks: %v", warmup, err)
+		}
+		if want := 2 + min(warmup, 1); ticks != 6 || world.Registry().Count() != want {
+			t.Fatalf("expected 6 ticks and %d entities, got %d and %d", want, ticks, world.Registry().Count())
+		}
+	}
+}
+
+func TestReplayDetectsDivergence(t *testing.T) {
+	recording := recordSession(t, 0)
+	world, scheduler, inputs := newReplayRig(t, 3)
+	replayer, err := ecs.NewReplayer(scheduler, world, bytes.NewReader(recording), inputs)
+	if err != nil {
+		t.Fatalf("new replayer: %v", err)
+	}
+	ticks, err := replayer.Run(context.Background())
+	var divergence *ecs.ReplayDivergence
+	if !errors.Is(err, ecs.ErrReplayDiverged) || !errors.As(err, &divergence) {
+		t.Fatalf("expected divergence, got %v", err)
+	}
+	// Nothing moves until the first spawn at tick 1.
+	if ticks != 1 || divergence.Tick != 1 {
+		t.Fatalf("expected divergence at tick 1, got tick %d after %d ticks", divergence.Tick, ticks)
+	}
+
+	if _, err := ecs.NewReplayer(scheduler, world, bytes.NewReader([]byte("garbage")), inputs); !errors.Is(err, ecs.ErrReplayInvalid) {
+		t.Fatalf("expected ErrReplayInvalid, got %v", err)
+	}
+}
+
+func TestRecorderStopsAfterUnrecordedTick(t *testing.T) {
+	world, scheduler, inputs := newReplayRig(t, 2)
+	inputs["extend"] = func(world *ecs.World, _ []byte) error {
+		// A component without a codec makes the world impossible to hash.
+		return world.RegisterComponent("Untracked", ecsstorage.NewDenseStrategy())
+	}
+	var recording bytes.Buffer
+	recorder, err := ecs.NewRecorder(scheduler, world, &recording, inputs)
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	if err := recorder.Inject("extend", nil); err != nil {
+		t.Fatalf("inject: %v", err)
+	}
+	ctx := context.Background()
+	if err := recorder.Tick(ctx, time.Millisecond); !errors.Is(err, ecs.ErrCodecNotRegistered) {
+		t.Fatalf("expected the hash to fail, got %v", err)
+	}
+	if err := recorder.Tick(ctx, time.Millisecond); !errors.Is(err, ecs.ErrCodecNotRegistered) {
+		t.Fatalf("expected later ticks to be refused, got %v", err)
+	}
+	if tick := world.ChangeTick(); tick != 1 {
+		t.Fatalf("expected only the first tick to run, change tick is %d", tick)
+	}
+}
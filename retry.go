@@ -0,0 +1,73 @@
+package ecs
+
+import "fmt"
+
+// retryState tracks a system's failure streak across ticks.
+type retryState struct {
+	attempts    int
+	failedTicks int
+	nextAttempt uint64
+	open        bool
+}
+
+// resolveRetryPolicy picks the descriptor policy, then the group policy, then
+// the behaviour implied by the group's ErrorPolicy.
+func resolveRetryPolicy(desc SystemDescriptor, group *workGroupState) RetryPolicy {
+	switch {
+	case desc.Retry != nil:
+		return *desc.Retry
+	case group.retry != nil:
+		return *group.retry
+	case group.policy == ErrorPolicyRetry:
+		return RetryPolicy{MaxAttempts: 2}
+	default:
+		return RetryPolicy{MaxAttempts: 1}
+	}
+}
+
+// allows reports whether another attempt may follow the given failure.
+func (p RetryPolicy) allows(err error, attempts int) bool {
+	if attempts >= p.MaxAttempts {
+		return false
+	}
+	return p.RetryIf == nil || p.RetryIf(err)
+}
+
+// ResetCircuit re-enables a system disabled by its circuit breaker.
+func (s *basicScheduler) ResetCircuit(group WorkGroupID, system string) error {
+	if system == "" {
+		return fmt.Errorf("ecs: reset circuit requires a system name")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, idx, err := s.lookupLocked(group, system)
+	if err != nil {
+		return err
+	}
+	s.pendingResets = append(s.pendingResets, circuitReset{group: state, index: idx})
+	return nil
+}
+
+type circuitReset struct {
+	group *workGroupState
+	index int
+}
+
+// applyCircuitResetsLocked clears circuits requested since the previous tick.
+// Resets are deferred so they never race with a running work group.
+func (s *basicScheduler) applyCircuitResetsLocked() {
+	for _, reset := range s.pendingResets {
+		reset.group.retries[reset.index] = retryState{}
+	}
+	s.pendingResets = nil
+}
+
+func (b *schedulerBuilder) WithRetryPolicy(id WorkGroupID, policy RetryPolicy) SchedulerBuilder {
+	b.scheduler.mu.Lock()
+	b.scheduler.retryPolicies[id] = policy
+	if state, ok := b.scheduler.groupStates[id]; ok {
+		state.retry = &policy
+	}
+	b.scheduler.mu.Unlock()
+	return b
+}
@@ -0,0 +1,168 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+var errTransient = errors.New("transient")
+
+// scriptedSystem fails with the scripted errors in order, then succeeds, and
+// records the tick of every attempt.
+type scriptedSystem struct {
+	name     string
+	retry    *ecs.RetryPolicy
+	failures []error
+	attempts []uint64
+}
+
+func (s *scriptedSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: s.name, Retry: s.retry}
+}
+
+func (s *scriptedSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	s.attempts = append(s.attempts, exec.TickIndex())
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+		return ecs.SystemResult{Err: err}
+	}
+	return ecs.SystemResult{}
+}
+
+type circuitRecorder struct {
+	recordingObserver
+	opened []ecs.CircuitEvent
+}
+
+func (r *circuitRecorder) SystemCircuitOpened(event ecs.CircuitEvent) {
+	r.opened = append(r.opened, event)
+}
+
+func repeatErr(err error, n int) []error {
+	out := make([]error, n)
+	for i := range out {
+		out[i] = err
+	}
+	return out
+}
+
+func newRetryScheduler(t *testing.T, cfg ecs.WorkGroupConfig) ecs.Scheduler {
+	t.Helper()
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return scheduler
+}
+
+func TestRetryPolicyImmediateAttempts(t *testing.T) {
+	sys := &scriptedSystem{name: "flaky", failures: repeatErr(errTransient, 2)}
+	retry := ecs.RetryPolicy{MaxAttempts: 3}
+	scheduler := newRetryScheduler(t, ecs.WorkGroupConfig{ID: "g", Retry: &retry, Systems: []ecs.System{sys}})
+
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if !reflect.DeepEqual(sys.attempts, []uint64{0, 0, 0}) {
+		t.Fatalf("expected three attempts in tick 0, got %v", sys.attempts)
+	}
+}
+
+func TestRetryPolicyRetryIfFiltersErrors(t *testing.T) {
+	fatal := errors.New("fatal")
+	sys := &scriptedSystem{name: "strict", failures: []error{fatal}, retry: &ecs.RetryPolicy{
+		MaxAttempts: 5,
+		RetryIf:     func(err error) bool { return errors.Is(err, errTransient) },
+	}}
+	scheduler := newRetryScheduler(t, ecs.WorkGroupConfig{ID: "g", Systems: []ecs.System{sys}})
+
+	if err := scheduler.Tick(context.Background(), time.Millisecond); !errors.Is(err, fatal) {
+		t.Fatalf("expected non-retryable error to surface, got %v", err)
+	}
+	if len(sys.attempts) != 1 {
+		t.Fatalf("expected a single attempt, got %v", sys.attempts)
+	}
+}
+
+func TestRetryPolicyBackoffInTicks(t *testing.T) {
+	sys := &scriptedSystem{name: "remote", failures: repeatErr(errTransient, 10)}
+	after := &scriptedSystem{name: "after"}
+	scheduler := newRetryScheduler(t, ecs.WorkGroupConfig{ID: "g", Systems: []ecs.System{sys, after}})
+	scheduler.Builder().WithRetryPolicy("g", ecs.RetryPolicy{MaxAttempts: 3, BackoffTicks: 2})
+	observer := &recordingObserver{}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{Observer: observer})
+
+	// Each failed attempt reaches the abort policy and fails the tick without
+	// advancing it, while waiting ticks skip the system and let the rest of
+	// the group run.
+	for call := 0; call < 7; call++ {
+		err := scheduler.Tick(context.Background(), time.Millisecond)
+		if failing := call%3 == 0; failing != errors.Is(err, errTransient) {
+			t.Fatalf("call %d: unexpected result %v", call, err)
+		}
+	}
+	if !reflect.DeepEqual(sys.attempts, []uint64{0, 2, 4}) {
+		t.Fatalf("unexpected attempt ticks %v", sys.attempts)
+	}
+	if !reflect.DeepEqual(after.attempts, []uint64{0, 1, 2, 3}) {
+		t.Fatalf("expected later systems to run only on waiting ticks, got %v", after.attempts)
+	}
+
+	var retries []int
+	for _, summary := range observer.summaries {
+		for _, system := range summary.Systems {
+			if system.Name == "remote" && system.Status == ecs.SystemStatusFailed {
+				retries = append(retries, system.Retries)
+			}
+		}
+	}
+	if !reflect.DeepEqual(retries, []int{0, 1, 2}) {
+		t.Fatalf("unexpected reported retries %v", retries)
+	}
+}
+
+func TestRetryPolicyCircuitBreaker(t *testing.T) {
+	sys := &scriptedSystem{
+		name:     "broken",
+		failures: repeatErr(errTransient, 2),
+		retry:    &ecs.RetryPolicy{CircuitBreakAfter: 2},
+	}
+	scheduler := newRetryScheduler(t, ecs.WorkGroupConfig{ID: "g", ErrorPolicy: ecs.ErrorPolicyContinue, Systems: []ecs.System{sys}})
+	observer := &circuitRecorder{}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{
+		Observer:    observer,
+		Observation: ecs.ObservationSettings{EnableStructuredLogging: true},
+	})
+
+	if err := scheduler.Run(context.Background(), 4, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !reflect.DeepEqual(sys.attempts, []uint64{0, 1}) {
+		t.Fatalf("expected the circuit to stop attempts after two failing ticks, got %v", sys.attempts)
+	}
+	if len(observer.opened) != 1 || observer.opened[0].System != "broken" || observer.opened[0].Tick != 1 || observer.opened[0].Failures != 2 {
+		t.Fatalf("unexpected circuit events: %+v", observer.opened)
+	}
+
+	if err := scheduler.ResetCircuit("g", "broken"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if err := scheduler.ResetCircuit("g", "missing"); err == nil {
+		t.Fatalf("expected unknown system to be rejected")
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if !reflect.DeepEqual(sys.attempts, []uint64{0, 1, 4}) {
+		t.Fatalf("expected the system to run again after reset, got %v", sys.attempts)
+	}
+}
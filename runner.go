@@ -0,0 +1,159 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultMaxStepsPerFrame bounds catch-up work when FixedStepConfig leaves it unset.
+const defaultMaxStepsPerFrame = 5
+
+// Clock abstracts wall-clock time so runners can be driven deterministically.
+type Clock interface {
+	Now() time.Time
+	// Sleep blocks for d or until ctx is done, returning ctx.Err() in that case.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SystemClock returns a Clock backed by the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// FrameStats describes one iteration of a FixedStepRunner.
+type FrameStats struct {
+	Frame   uint64
+	Ticks   uint64        // total ticks executed by the runner so far
+	Steps   int           // ticks executed during this frame
+	Elapsed time.Duration // wall time since the previous frame
+	Lag     time.Duration // accumulated time not yet simulated
+	Alpha   float64       // Lag as a fraction of the step, for render interpolation
+	Overrun bool          // the frame hit MaxStepsPerFrame and discarded time
+	Dropped time.Duration // simulated time discarded by the overrun
+}
+
+// FrameObserver receives statistics after every runner frame.
+type FrameObserver interface {
+	FrameCompleted(stats FrameStats)
+}
+
+// FixedStepConfig configures a FixedStepRunner.
+type FixedStepConfig struct {
+	// Step is the simulated duration of one tick, e.g. time.Second / 30.
+	Step time.Duration
+	// MaxStepsPerFrame caps catch-up ticks per frame; defaults to 5.
+	MaxStepsPerFrame int
+	// Clock defaults to SystemClock.
+	Clock    Clock
+	Observer FrameObserver
+}
+
+// FixedStepRunner drives a scheduler from wall-clock time at a fixed tick
+// rate. Elapsed time accumulates and is consumed in Step-sized ticks; when the
+// backlog exceeds MaxStepsPerFrame the remainder is dropped and reported as an
+// overrun instead of spiralling.
+type FixedStepRunner struct {
+	scheduler Scheduler
+	step      time.Duration
+	maxSteps  int
+	clock     Clock
+	observer  FrameObserver
+}
+
+// NewFixedStepRunner validates cfg and binds a runner to the scheduler.
+func NewFixedStepRunner(scheduler Scheduler, cfg FixedStepConfig) (*FixedStepRunner, error) {
+	if scheduler == nil {
+		return nil, fmt.Errorf("ecs: fixed step runner requires a scheduler")
+	}
+	if cfg.Step <= 0 {
+		return nil, fmt.Errorf("ecs: fixed step runner requires a positive step, got %s", cfg.Step)
+	}
+	r := &FixedStepRunner{
+		scheduler: scheduler,
+		step:      cfg.Step,
+		maxSteps:  cfg.MaxStepsPerFrame,
+		clock:     cfg.Clock,
+		observer:  cfg.Observer,
+	}
+	if r.maxSteps <= 0 {
+		r.maxSteps = defaultMaxStepsPerFrame
+	}
+	if r.clock == nil {
+		r.clock = SystemClock()
+	}
+	return r, nil
+}
+
+// Run ticks the scheduler until ctx is cancelled, which is a clean stop and
+// returns nil. Tick errors stop the runner and are returned.
+func (r *FixedStepRunner) Run(ctx context.Context) error {
+	var (
+		lag   time.Duration
+		frame uint64
+		ticks uint64
+	)
+	last := r.clock.Now()
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		now := r.clock.Now()
+		elapsed := now.Sub(last)
+		last = now
+		if elapsed > 0 {
+			lag += elapsed
+		}
+
+		stats := FrameStats{Frame: frame, Elapsed: elapsed}
+		for lag >= r.step && stats.Steps < r.maxSteps {
+			if err := r.scheduler.Tick(ctx, r.step); err != nil {
+				if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+					return nil
+				}
+				return err
+			}
+			lag -= r.step
+			stats.Steps++
+			ticks++
+		}
+		if lag >= r.step {
+			stats.Overrun = true
+			stats.Dropped = lag - lag%r.step
+			lag %= r.step
+		}
+		stats.Ticks = ticks
+		stats.Lag = lag
+		stats.Alpha = float64(lag) / float64(r.step)
+		if r.observer != nil {
+			r.observer.FrameCompleted(stats)
+		}
+		frame++
+
+		if err := r.clock.Sleep(ctx, r.step-lag); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package ecs_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+// fakeClock advances only when the runner sleeps. Each sleep also adds the
+// next scripted stall, simulating frames that took longer than planned.
+type fakeClock struct {
+	now    time.Time
+	stalls []time.Duration
+	sleeps int
+	limit  int
+	cancel context.CancelFunc
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.now = c.now.Add(d)
+	if c.sleeps < len(c.stalls) {
+		c.now = c.now.Add(c.stalls[c.sleeps])
+	}
+	c.sleeps++
+	if c.sleeps >= c.limit {
+		c.cancel()
+	}
+	return ctx.Err()
+}
+
+type recordingFrames struct {
+	frames []ecs.FrameStats
+}
+
+func (r *recordingFrames) FrameCompleted(stats ecs.FrameStats) {
+	r.frames = append(r.frames, stats)
+}
+
+func TestFixedStepRunnerAccumulatesAndCaps(t *testing.T) {
+	world := ecs.NewWorld()
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	var dts []time.Duration
+	sys := &testSystem{name: "sim", deferCmd: func(ctx ecs.ExecutionContext) {
+		dts = append(dts, ctx.TimeDelta())
+	}}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "sim", Systems: []ecs.System{sys}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	const step = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clock := &fakeClock{
+		now: time.Unix(0, 0),
+		// Frame 1 stalls half a step, frame 2 stalls 10 steps.
+		stalls: []time.Duration{0, step / 2, 10 * step},
+		limit:  4,
+		cancel: cancel,
+	}
+	frames := &recordingFrames{}
+	runner, err := ecs.NewFixedStepRunner(scheduler, ecs.FixedStepConfig{
+		Step:             step,
+		MaxStepsPerFrame: 3,
+		Clock:            clock,
+		Observer:         frames,
+	})
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	if err := runner.Run(ctx); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if len(frames.frames) != 4 {
+		t.Fatalf("expected 4 frames, got %d", len(frames.frames))
+	}
+	type want struct {
+		steps   int
+		lag     time.Duration
+		overrun bool
+		dropped time.Duration
+	}
+	wants := []want{
+		{steps: 0, lag: 0},
+		{steps: 1, lag: 0},
+		{steps: 1, lag: step / 2},
+		{steps: 3, lag: 0, overrun: true, dropped: 8 * step},
+	}
+	for i, w := range wants {
+		got := frames.frames[i]
+		if got.Steps != w.steps || got.Lag != w.lag || got.Overrun != w.overrun || got.Dropped != w.dropped {
+			t.Fatalf("frame %d: got %+v want %+v", i, got, w)
+		}
+		if math.Abs(got.Alpha-float64(w.lag)/float64(step)) > 1e-9 {
+			t.Fatalf("frame %d: unexpected alpha %v", i, got.Alpha)
+		}
+	}
+	if frames.frames[3].Ticks != 5 || len(dts) != 5 {
+		t.Fatalf("expected 5 ticks, got %d (systems ran %d times)", frames.frames[3].Ticks, len(dts))
+	}
+	for _, dt := range dts {
+		if dt != step {
+			t.Fatalf("expected fixed dt %s, got %s", step, dt)
+		}
+	}
+}
+
+func TestFixedStepRunnerReturnsTickErrors(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	failing := &testSystem{name: "fail", failLimit: 1}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "g", Systems: []ecs.System{failing}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clock := &fakeClock{now: time.Unix(0, 0), limit: 10, cancel: cancel}
+	runner, err := ecs.NewFixedStepRunner(scheduler, ecs.FixedStepConfig{Step: time.Millisecond, Clock: clock})
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	if err := runner.Run(ctx); err == nil {
+		t.Fatalf("expected tick error to stop the runner")
+	}
+
+	if _, err := ecs.NewFixedStepRunner(scheduler, ecs.FixedStepConfig{}); err == nil {
+		t.Fatalf("expected zero step to be rejected")
+	}
+}
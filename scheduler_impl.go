@@ -2,11 +2,13 @@ package ecs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
 	"runtime/trace"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -24,13 +26,18 @@ func NewScheduler(world *World) (Scheduler, error) {
 		logger:            noopLogger{},
 		tracer:            noopTracer{},
 		errorPolicies:     make(map[WorkGroupID]ErrorPolicy),
+		retryPolicies:     make(map[WorkGroupID]RetryPolicy),
 		componentOwners:   make(map[ComponentType]WorkGroupID),
 		resourceOwners:    make(map[string]WorkGroupID),
 		resourceReaders:   make(map[string]map[WorkGroupID]struct{}),
+		eventOwners:       make(map[string]WorkGroupID),
 		observer:          noopObserver{},
 	}
+	s.lifetime, s.stopSpanning = context.WithCancel(context.Background())
 	s.applyInstrumentation(InstrumentationConfig{})
-	s.rebuildOrder()
+	if err := s.rebuildOrder(); err != nil {
+		return nil, err
+	}
 	return s, nil
 }
 
@@ -40,7 +47,9 @@ type basicScheduler struct {
 	groupStates       map[WorkGroupID]*workGroupState
 	registrationOrder []WorkGroupID
 	orderedGroups     []*workGroupState
+	orderSuccessors   map[WorkGroupID]map[WorkGroupID]struct{}
 	syncOrder         []WorkGroupID
+	parallelGroups    bool
 	pool              *CommandBufferPool
 	asyncPool         *workerPool
 	logger            Logger
@@ -48,11 +57,25 @@ type basicScheduler struct {
 	instrumentation   InstrumentationConfig
 	observer          SchedulerObserver
 	errorPolicies     map[WorkGroupID]ErrorPolicy
+	retryPolicies     map[WorkGroupID]RetryPolicy
+	pendingResets     []circuitReset
+	pendingControl    []func()
+	detached          []*workGroupState
+	retiring          []*workGroupState // unregistered groups whose SpanTicks job still runs
+	ticking           bool
+	tickDone          chan struct{}
+	shutdownPolicy    ShutdownPolicy
+	closing           bool
+	closed            bool
+	lifetime          context.Context // parent of SpanTicks jobs, which outlive a tick
+	stopSpanning      context.CancelFunc
+	builderErr        error // options rejected since the last Build
 	tickIndex         uint64
 	asyncWorkers      int
 	componentOwners   map[ComponentType]WorkGroupID
 	resourceOwners    map[string]WorkGroupID
 	resourceReaders   map[string]map[WorkGroupID]struct{}
+	eventOwners       map[string]WorkGroupID
 }
 
 type workGroupState struct {
@@ -61,12 +84,24 @@ type workGroupState struct {
 	systems        []System
 	interval       TickInterval
 	lastRun        uint64
+	systemRuns     []uint64 // tick+1 of each system's last completed run, 0 if never
+	retries        []retryState
+	retry          *RetryPolicy
+	paused         bool
+	systemPaused   []bool
+	spanTicks      bool
+	inflight       *spanningJob
 	policy         ErrorPolicy
 	priority       int
 	readSet        map[ComponentType]struct{}
 	writeSet       map[ComponentType]struct{}
 	resourceReads  map[string]struct{}
 	resourceWrites map[string]struct{}
+	eventReads     map[string]struct{}
+	eventWrites    map[string]struct{}
+	labels         []string
+	before         []string
+	after          []string
 }
 
 type schedulerBuilder struct {
@@ -86,8 +121,14 @@ func (s *basicScheduler) Builder() SchedulerBuilder {
 
 func (b *schedulerBuilder) WithSyncOrder(order []WorkGroupID) SchedulerBuilder {
 	b.scheduler.mu.Lock()
+	previous := b.scheduler.syncOrder
 	b.scheduler.syncOrder = append([]WorkGroupID(nil), order...)
-	b.scheduler.rebuildOrder()
+	if err := b.scheduler.rebuildOrder(); err != nil {
+		b.scheduler.syncOrder = previous
+		err = fmt.Errorf("ecs: sync order rejected: %w", err)
+		b.scheduler.logger.Error("sync order rejected", "err", err)
+		b.scheduler.builderErr = errors.Join(b.scheduler.builderErr, err)
+	}
 	b.scheduler.mu.Unlock()
 	return b
 }
@@ -102,7 +143,7 @@ func (b *schedulerBuilder) WithAsyncWorkers(count int) SchedulerBuilder {
 		b.scheduler.asyncPool.Close()
 		b.scheduler.asyncPool = nil
 	}
-	if count > 0 {
+	if count > 0 && !b.scheduler.closed {
 		b.scheduler.asyncPool = newWorkerPool(count)
 	}
 	b.scheduler.mu.Unlock()
@@ -130,9 +171,25 @@ func (b *schedulerBuilder) WithInstrumentation(cfg InstrumentationConfig) Schedu
 	return b
 }
 
+// WithParallelGroups lets non-conflicting synchronized groups run concurrently
+// on the worker pool. Commands are still applied in declared group order.
+func (b *schedulerBuilder) WithParallelGroups(enabled bool) SchedulerBuilder {
+	b.scheduler.mu.Lock()
+	b.scheduler.parallelGroups = enabled
+	if enabled {
+		b.scheduler.ensureAsyncPoolLocked()
+	}
+	b.scheduler.mu.Unlock()
+	return b
+}
+
 func (b *schedulerBuilder) Build(world *World) (Scheduler, error) {
 	b.scheduler.mu.Lock()
 	defer b.scheduler.mu.Unlock()
+	if err := b.scheduler.builderErr; err != nil {
+		b.scheduler.builderErr = nil
+		return nil, err
+	}
 	if world != nil {
 		b.scheduler.world = world
 	} else if b.scheduler.world == nil {
@@ -143,7 +200,12 @@ func (b *schedulerBuilder) Build(world *World) (Scheduler, error) {
 
 func (s *basicScheduler) applyInstrumentation(cfg InstrumentationConfig) {
 	s.instrumentation = cfg
-	if !cfg.EnableTrace {
+	switch {
+	case cfg.Tracer != nil:
+		s.tracer = cfg.Tracer
+	case cfg.EnableTrace:
+		s.tracer = RuntimeTracer()
+	default:
 		s.tracer = noopTracer{}
 	}
 	s.observer = buildObserverChain(s.logger, cfg)
@@ -157,20 +219,18 @@ func (s *basicScheduler) RegisterWorkGroup(cfg WorkGroupConfig) (WorkGroupHandle
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.closing {
+		return nil, ErrSchedulerClosed
+	}
 	if _, exists := s.groupStates[cfg.ID]; exists {
 		return nil, fmt.Errorf("ecs: work group %s already registered", cfg.ID)
 	}
 
-	if cfg.Mode == WorkGroupModeAsync && s.asyncPool == nil {
-		workers := s.asyncWorkers
-		if workers <= 0 {
-			workers = runtime.NumCPU()
-			if workers <= 0 {
-				workers = 1
-			}
-			s.asyncWorkers = workers
-		}
-		s.asyncPool = newWorkerPool(workers)
+	if cfg.SpanTicks && cfg.Mode != WorkGroupModeAsync {
+		return nil, fmt.Errorf("ecs: work group %s: SpanTicks requires WorkGroupModeAsync", cfg.ID)
+	}
+	if cfg.Mode == WorkGroupModeAsync {
+		s.ensureAsyncPoolLocked()
 	}
 
 	systems := make([]System, 0, len(cfg.Systems))
@@ -181,15 +241,29 @@ func (s *basicScheduler) RegisterWorkGroup(cfg WorkGroupConfig) (WorkGroupHandle
 		systems = append(systems, sys)
 	}
 
+	systems, err := orderSystems(cfg.ID, systems)
+	if err != nil {
+		return nil, err
+	}
+
 	reads, writes, resourceReads, resourceWrites, err := validateSystemsAccess(cfg.Mode, systems)
 	if err != nil {
 		return nil, err
 	}
+	eventReads, eventWrites, err := validateEventAccess(cfg.Mode, systems)
+	if err != nil {
+		return nil, err
+	}
 
 	state := &workGroupState{
 		id:             cfg.ID,
 		mode:           cfg.Mode,
 		systems:        systems,
+		systemRuns:     make([]uint64, len(systems)),
+		retries:        make([]retryState, len(systems)),
+		systemPaused:   make([]bool, len(systems)),
+		spanTicks:      cfg.SpanTicks,
+		retry:          s.resolveGroupRetry(cfg.ID, cfg.Retry),
 		interval:       cfg.Interval,
 		policy:         s.resolvePolicy(cfg.ID, cfg.ErrorPolicy),
 		priority:       cfg.Priority,
@@ -197,14 +271,27 @@ func (s *basicScheduler) RegisterWorkGroup(cfg WorkGroupConfig) (WorkGroupHandle
 		writeSet:       writes,
 		resourceReads:  resourceReads,
 		resourceWrites: resourceWrites,
+		eventReads:     eventReads,
+		eventWrites:    eventWrites,
+		labels:         append([]string(nil), cfg.Labels...),
+		before:         append([]string(nil), cfg.Before...),
+		after:          append([]string(nil), cfg.After...),
 	}
 
 	if err := s.checkCrossGroupConflicts(state); err != nil {
 		return nil, err
 	}
+	if err := initSystems(cfg.ID, systems, s.world); err != nil {
+		return nil, err
+	}
 
 	s.groupStates[cfg.ID] = state
 	s.registrationOrder = append(s.registrationOrder, cfg.ID)
+	if err := s.rebuildOrder(); err != nil {
+		delete(s.groupStates, cfg.ID)
+		s.registrationOrder = s.registrationOrder[:len(s.registrationOrder)-1]
+		return nil, errors.Join(err, shutdownSystems(context.Background(), state, s.world))
+	}
 	for comp := range state.writeSet {
 		s.componentOwners[comp] = state.id
 	}
@@ -217,11 +304,41 @@ func (s *basicScheduler) RegisterWorkGroup(cfg WorkGroupConfig) (WorkGroupHandle
 		}
 		s.resourceReaders[res][state.id] = struct{}{}
 	}
-	s.rebuildOrder()
+	for name := range state.eventWrites {
+		s.eventOwners[name] = state.id
+	}
 
 	return workGroupHandle{id: cfg.ID}, nil
 }
 
+// ensureAsyncPoolLocked starts the worker pool, sizing it to the CPU count
+// when no explicit worker count was configured.
+func (s *basicScheduler) ensureAsyncPoolLocked() {
+	if s.asyncPool != nil || s.closed {
+		return
+	}
+	workers := s.asyncWorkers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+		if workers <= 0 {
+			workers = 1
+		}
+		s.asyncWorkers = workers
+	}
+	s.asyncPool = newWorkerPool(workers)
+}
+
+func (s *basicScheduler) resolveGroupRetry(id WorkGroupID, supplied *RetryPolicy) *RetryPolicy {
+	if supplied != nil {
+		policy := *supplied
+		return &policy
+	}
+	if policy, ok := s.retryPolicies[id]; ok {
+		return &policy
+	}
+	return nil
+}
+
 func (s *basicScheduler) resolvePolicy(id WorkGroupID, supplied ErrorPolicy) ErrorPolicy {
 	if supplied != 0 {
 		return supplied
@@ -299,7 +416,45 @@ func validateSystemsAccess(mode WorkGroupMode, systems []System) (map[ComponentT
 	return reads, writes, resourceReads, resourceWrites, nil
 }
 
+// validateEventAccess collects event channel claims. Each channel has a single
+// writing system; any number of systems may read it.
+func validateEventAccess(mode WorkGroupMode, systems []System) (map[string]struct{}, map[string]struct{}, error) {
+	reads := make(map[string]struct{})
+	writes := make(map[string]struct{})
+	writeOwners := make(map[string]string)
+	for _, sys := range systems {
+		desc := sys.Descriptor()
+		name := desc.Name
+		if name == "" {
+			name = "<unnamed>"
+		}
+		for _, ev := range desc.Events {
+			if ev.Name == "" {
+				continue
+			}
+			if ev.Mode != AccessModeWrite {
+				reads[ev.Name] = struct{}{}
+				continue
+			}
+			if mode == WorkGroupModeAsync {
+				return nil, nil, fmt.Errorf("%w: %s emits %s", ErrAsyncEventWritesNotSupported, name, ev.Name)
+			}
+			if owner, exists := writeOwners[ev.Name]; exists {
+				return nil, nil, fmt.Errorf("%w: %s and %s both write event %s", ErrDuplicateEventWriteAccess, owner, name, ev.Name)
+			}
+			writeOwners[ev.Name] = name
+			writes[ev.Name] = struct{}{}
+		}
+	}
+	return reads, writes, nil
+}
+
 func (s *basicScheduler) checkCrossGroupConflicts(state *workGroupState) error {
+	for name := range state.eventWrites {
+		if owner, exists := s.eventOwners[name]; exists && owner != state.id {
+			return fmt.Errorf("%w: %s already writes event %s", ErrDuplicateEventWriteAccess, owner, name)
+		}
+	}
 	for comp := range state.writeSet {
 		if owner, exists := s.componentOwners[comp]; exists && owner != state.id {
 			return fmt.Errorf("%w: %s already owns component %s", ErrDuplicateWriteAccess, owner, comp)
@@ -325,13 +480,19 @@ func (s *basicScheduler) checkCrossGroupConflicts(state *workGroupState) error {
 	return nil
 }
 
-func (s *basicScheduler) rebuildOrder() {
-	ordered := make([]*workGroupState, 0, len(s.groupStates))
+// rebuildOrder seeds the group sequence from the sync order followed by
+// registration order, then applies Before/After constraints. The previous
+// order is kept when the constraints contain a cycle or link an async group.
+func (s *basicScheduler) rebuildOrder() error {
+	seeded := make([]*workGroupState, 0, len(s.groupStates))
 	seen := make(map[WorkGroupID]struct{}, len(s.groupStates))
 
 	for _, id := range s.syncOrder {
 		if state, ok := s.groupStates[id]; ok {
-			ordered = append(ordered, state)
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seeded = append(seeded, state)
 			seen[id] = struct{}{}
 		}
 	}
@@ -341,35 +502,119 @@ func (s *basicScheduler) rebuildOrder() {
 			continue
 		}
 		if state, ok := s.groupStates[id]; ok {
-			ordered = append(ordered, state)
+			seeded = append(seeded, state)
 			seen[id] = struct{}{}
 		}
 	}
 
+	nodes := make([]orderNode, len(seeded))
+	for i, state := range seeded {
+		nodes[i] = orderNode{
+			name:   string(state.id),
+			names:  append([]string{string(state.id)}, state.labels...),
+			before: state.before,
+			after:  state.after,
+		}
+	}
+	order, succ, err := topoOrder(nodes)
+	if err != nil {
+		return fmt.Errorf("ecs: work groups: %w", err)
+	}
+
+	ordered := make([]*workGroupState, len(order))
+	for i, idx := range order {
+		ordered[i] = seeded[idx]
+	}
+	successors := make(map[WorkGroupID]map[WorkGroupID]struct{})
+	for from, targets := range succ {
+		for _, to := range targets {
+			if seeded[from].mode == WorkGroupModeAsync || seeded[to].mode == WorkGroupModeAsync {
+				return fmt.Errorf("%w: %s before %s", ErrAsyncOrdering, seeded[from].id, seeded[to].id)
+			}
+			id := seeded[from].id
+			if successors[id] == nil {
+				successors[id] = make(map[WorkGroupID]struct{})
+			}
+			successors[id][seeded[to].id] = struct{}{}
+		}
+	}
 	s.orderedGroups = ordered
+	s.orderSuccessors = successors
+	return nil
 }
 
 func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 	buf := s.pool.Get()
 	defer s.pool.Put(buf)
 
-	s.mu.RLock()
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return ErrSchedulerClosed
+	}
+	s.applyPendingControlLocked()
+	s.applyCircuitResetsLocked()
+	detached := s.takeDetachedLocked()
+	retiring := append([]*workGroupState(nil), s.retiring...)
+	s.ticking = true
+	tickDone := make(chan struct{})
+	s.tickDone = tickDone
 	groups := append([]*workGroupState(nil), s.orderedGroups...)
+	successors := s.orderSuccessors
+	var stagePool *workerPool
+	if s.parallelGroups {
+		stagePool = s.asyncPool
+	}
 	tracer := s.tracer
 	logger := s.logger
 	world := s.world
 	tick := s.tickIndex
-	s.mu.RUnlock()
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.ticking = false
+		s.tickDone = nil
+		s.mu.Unlock()
+		close(tickDone)
+	}()
+	if err := shutdownDetached(ctx, detached, world); err != nil {
+		logger.Error("work group shutdown error", "err", err)
+	}
 
+	ctx, tickSpan := tracer.Start(ctx, "ecs.tick")
+	defer tickSpan.End()
+	annotateTrace(ctx, tracer, "tick", strconv.FormatUint(tick, 10))
+
+	world.changes.setTick(tick)
 	executedGroups := make([]WorkGroupID, 0, len(groups))
 	asyncHandles := make([]*jobHandle, 0)
 	asyncGroupIDs := make([]WorkGroupID, 0)
 
+	var stage []*workGroupState
+	flushStage := func() error {
+		if len(stage) == 0 {
+			return nil
+		}
+		executed, err := s.runStage(ctx, stagePool, stage, world, dt, tick, buf, logger, tracer)
+		stage = stage[:0]
+		executedGroups = append(executedGroups, executed...)
+		return err
+	}
+
 	for _, group := range groups {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		if !shouldRunTick(tick, group.interval) {
+		if group.paused || !shouldRunTick(tick, group.interval) {
+			continue
+		}
+		if group.spanTicks {
+			if group.inflight == nil {
+				group.inflight = &spanningJob{
+					handle:  s.dispatchSpanning(group, world.spanningView(group.readSet), dt, tick, logger, tracer),
+					started: tick,
+				}
+			}
 			continue
 		}
 		if group.mode == WorkGroupModeAsync {
@@ -378,6 +623,15 @@ func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 			asyncGroupIDs = append(asyncGroupIDs, group.id)
 			continue
 		}
+		if stagePool != nil {
+			if stageConflicts(stage, group, successors) {
+				if err := flushStage(); err != nil {
+					return err
+				}
+			}
+			stage = append(stage, group)
+			continue
+		}
 		summary, err := s.runWorkGroup(ctx, group, world, dt, tick, buf, logger, tracer, false)
 		if err != nil {
 			if group.policy == ErrorPolicyContinue {
@@ -391,9 +645,18 @@ func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 		executedGroups = append(executedGroups, group.id)
 		s.publishWorkGroupSummary(summary)
 	}
+	if err := flushStage(); err != nil {
+		return err
+	}
 
+	// Commands applied from here on become visible to systems on the next tick.
+	world.changes.setTick(tick + 1)
 	for idx, handle := range asyncHandles {
+		waitCtx, waitSpan := tracer.Start(ctx, "async_wait:"+string(asyncGroupIDs[idx]))
+		waitStart := time.Now()
 		res := handle.Wait()
+		annotateTrace(waitCtx, tracer, "async_wait", time.Since(waitStart).String())
+		waitSpan.End()
 		if summary := res.Summary(); summary != nil {
 			s.publishWorkGroupSummary(*summary)
 		}
@@ -409,6 +672,10 @@ func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 			return err
 		}
 		if commands := res.Commands(); len(commands) > 0 {
+			if s.discardAsyncCommands() {
+				logger.Info("discarding async commands on shutdown", "group", string(asyncGroupIDs[idx]), "commands", len(commands))
+				continue
+			}
 			if err := world.ApplyCommands(commands); err != nil {
 				return err
 			}
@@ -416,6 +683,30 @@ func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 		executedGroups = append(executedGroups, asyncGroupIDs[idx])
 	}
 
+	for _, group := range groups {
+		if group.inflight == nil {
+			continue
+		}
+		res, done := group.inflight.handle.Poll()
+		if !done {
+			continue
+		}
+		started := group.inflight.started
+		group.inflight = nil
+		if err := s.finishSpanning(world, group, res, started, tick, logger); err != nil {
+			return err
+		}
+	}
+	for _, group := range retiring {
+		res, done := group.inflight.handle.Poll()
+		if !done {
+			continue
+		}
+		if err := s.retire(ctx, world, group, res, tick, logger); err != nil {
+			return err
+		}
+	}
+
 	drained := buf.Drain()
 	if len(drained) > 0 {
 		if err := world.ApplyCommands(drained); err != nil {
@@ -423,6 +714,8 @@ func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 		}
 	}
 
+	world.events.advance()
+
 	s.mu.Lock()
 	for _, id := range executedGroups {
 		if state, ok := s.groupStates[id]; ok {
@@ -433,8 +726,102 @@ func (s *basicScheduler) Tick(ctx context.Context, dt time.Duration) error {
 	s.mu.Unlock()
 	return nil
 }
+
+// runStage executes a set of mutually non-conflicting synchronized groups on
+// the worker pool. Each group records into its own buffer; buffers are merged
+// into buf and summaries published in declared order once every group is done.
+func (s *basicScheduler) runStage(ctx context.Context, pool *workerPool, stage []*workGroupState, world *World, dt time.Duration, tick uint64, buf *CommandBuffer, logger Logger, tracer Tracer) ([]WorkGroupID, error) {
+	handles := make([]*jobHandle, len(stage))
+	for i, group := range stage {
+		group := group
+		handles[i] = pool.Submit(ctx, func(jobCtx context.Context) jobResult {
+			jobBuf := s.pool.Get()
+			defer s.pool.Put(jobBuf)
+			summary, err := s.runWorkGroup(jobCtx, group, world, dt, tick, jobBuf, logger, tracer, false)
+			return jobResult{err: err, commands: jobBuf.Drain(), summary: &summary}
+		})
+	}
+	results := make([]jobResult, len(handles))
+	for i, handle := range handles {
+		results[i] = handle.Wait()
+	}
+
+	executed := make([]WorkGroupID, 0, len(stage))
+	var failure error
+	for i, group := range stage {
+		res := results[i]
+		if summary := res.Summary(); summary != nil {
+			s.publishWorkGroupSummary(*summary)
+		}
+		if failure != nil {
+			continue
+		}
+		if err := res.Err(); err != nil {
+			if group.policy != ErrorPolicyContinue {
+				failure = err
+				continue
+			}
+			logger.Error("work group error", "group", string(group.id), "err", err)
+		} else {
+			executed = append(executed, group.id)
+		}
+		for _, cmd := range res.Commands() {
+			buf.Push(cmd)
+		}
+	}
+	return executed, failure
+}
+
+// stageConflicts reports whether group must wait for the groups already in
+// stage, either because their access sets overlap or an ordering edge links them.
+func stageConflicts(stage []*workGroupState, group *workGroupState, successors map[WorkGroupID]map[WorkGroupID]struct{}) bool {
+	for _, other := range stage {
+		if _, ok := successors[other.id][group.id]; ok {
+			return true
+		}
+		if _, ok := successors[group.id][other.id]; ok {
+			return true
+		}
+		if groupsConflict(other, group) || groupsConflict(group, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupsConflict reports whether a writes anything b reads or writes.
+func groupsConflict(a, b *workGroupState) bool {
+	for comp := range a.writeSet {
+		if _, ok := b.readSet[comp]; ok {
+			return true
+		}
+		if _, ok := b.writeSet[comp]; ok {
+			return true
+		}
+	}
+	for res := range a.resourceWrites {
+		if _, ok := b.resourceReads[res]; ok {
+			return true
+		}
+		if _, ok := b.resourceWrites[res]; ok {
+			return true
+		}
+	}
+	for name := range a.eventWrites {
+		if _, ok := b.eventReads[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *basicScheduler) runWorkGroup(ctx context.Context, group *workGroupState, world *World, dt time.Duration, tick uint64, buf *CommandBuffer, logger Logger, tracer Tracer, async bool) (workGroupRunSummary, error) {
+	ctx, groupSpan := tracer.Start(ctx, "workgroup:"+string(group.id))
+	defer groupSpan.End()
 	groupLogger := logger.With("work_group", string(group.id))
+	s.mu.RLock()
+	commandObserver := s.instrumentation.CommandObserver
+	s.mu.RUnlock()
 	execCtx := &systemExecutionContext{
 		world:    world,
 		dt:       dt,
@@ -442,6 +829,8 @@ func (s *basicScheduler) runWorkGroup(ctx context.Context, group *workGroupState
 		logger:   groupLogger,
 		tracer:   tracer,
 		commands: buf,
+		group:    group.id,
+		observer: commandObserver,
 	}
 
 	summary := workGroupRunSummary{
@@ -456,7 +845,7 @@ func (s *basicScheduler) runWorkGroup(ctx context.Context, group *workGroupState
 	}
 
 	start := time.Now()
-	for _, system := range group.systems {
+	for idx, system := range group.systems {
 		if err := ctx.Err(); err != nil {
 			summary.err = err
 			summary.duration = time.Since(start)
@@ -464,42 +853,103 @@ func (s *basicScheduler) runWorkGroup(ctx context.Context, group *workGroupState
 		}
 		desc := system.Descriptor()
 		summary.systemsTotal++
+		skipped := SystemSummary{Name: desc.Name, Status: SystemStatusSkipped}
 		if !shouldRunTick(tick, desc.RunEvery) {
 			summary.systemsSkipped++
+			summary.systems = append(summary.systems, skipped)
+			continue
+		}
+		// SpanTicks jobs outlive the tick, so per-system state is read and
+		// stored under the lock that control calls and circuit resets take.
+		s.mu.RLock()
+		paused := group.systemPaused[idx]
+		loaded := group.retries[idx]
+		execCtx.lastRun = group.systemRuns[idx]
+		policy := resolveRetryPolicy(desc, group)
+		s.mu.RUnlock()
+		retry := loaded
+		if paused || retry.open || tick < retry.nextAttempt {
+			summary.systemsSkipped++
+			summary.systems = append(summary.systems, skipped)
 			continue
 		}
 		systemLogger := groupLogger.With("system", desc.Name)
 		execCtx.logger = systemLogger
+		execCtx.system = desc.Name
 
+		report := SystemSummary{Name: desc.Name}
+		systemCtx, systemSpan := tracer.Start(ctx, "system:"+desc.Name)
+		systemStart := time.Now()
 		snapshot := buf.Snapshot()
-		result := system.Run(ctx, execCtx)
+		result := runSystem(systemCtx, system, execCtx, desc.Name, group.id)
+		backoff := false
+		for result.Err != nil {
+			buf.Restore(snapshot)
+			execCtx.takeEvents(false)
+			retry.attempts++
+			if !policy.allows(result.Err, retry.attempts) {
+				break
+			}
+			if policy.BackoffTicks > 0 {
+				backoff = true
+				break
+			}
+			systemLogger.Error("system failed, retrying", "attempt", retry.attempts, "err", result.Err)
+			result = runSystem(systemCtx, system, execCtx, desc.Name, group.id)
+			if result.Err == nil {
+				systemLogger.Info("system retry succeeded")
+			}
+		}
+		report.Duration = time.Since(systemStart)
+		systemSpan.End()
+		// attempts counts the failures of the current streak, the last of
+		// which is this run when it failed.
+		report.Retries = retry.attempts
 		if result.Err != nil {
-			if group.policy == ErrorPolicyRetry {
-				systemLogger.Error("system failed, retrying", "err", result.Err)
-				buf.Restore(snapshot)
-				result = system.Run(ctx, execCtx)
-				if result.Err == nil {
-					systemLogger.Info("system retry succeeded")
-					if result.Skipped {
-						summary.systemsSkipped++
-					} else {
-						summary.systemsExecuted++
-						systemLogger.Info("system executed")
-					}
-					continue
-				}
+			report.Retries--
+			report.Status = SystemStatusFailed
+			report.Error = result.Err
+			summary.systems = append(summary.systems, report)
+			retry.failedTicks++
+			if policy.CircuitBreakAfter > 0 && retry.failedTicks >= policy.CircuitBreakAfter {
+				retry.open = true
+				systemLogger.Error("system circuit opened", "failures", retry.failedTicks, "err", result.Err)
+				summary.circuits = append(summary.circuits, CircuitEvent{
+					WorkGroupID: group.id,
+					System:      desc.Name,
+					Tick:        tick,
+					Failures:    retry.failedTicks,
+					Error:       result.Err,
+				})
 			}
-			buf.Restore(snapshot)
-			err := fmt.Errorf("ecs: system %s failed: %w", desc.Name, result.Err)
+			var err error
+			if backoff && !retry.open {
+				// The failed attempt still reaches the group's ErrorPolicy;
+				// only the retry waits.
+				retry.nextAttempt = tick + policy.BackoffTicks
+				systemLogger.Error("system failed, retrying after backoff", "attempt", retry.attempts, "next_tick", retry.nextAttempt, "err", result.Err)
+				err = fmt.Errorf("ecs: system %s failed, retrying at tick %d: %w", desc.Name, retry.nextAttempt, result.Err)
+			} else {
+				retry.attempts = 0
+				err = fmt.Errorf("ecs: system %s failed: %w", desc.Name, result.Err)
+			}
+			s.storeSystemRun(group, idx, loaded, retry, 0)
 			summary.err = err
 			summary.duration = time.Since(start)
 			return summary, err
 		}
+		report.Commands = buf.Len() - snapshot
+		execCtx.takeEvents(true)
 		if result.Skipped {
+			s.storeSystemRun(group, idx, loaded, retryState{}, 0)
 			summary.systemsSkipped++
+			report.Status = SystemStatusSkipped
+			summary.systems = append(summary.systems, report)
 			continue
 		}
+		s.storeSystemRun(group, idx, loaded, retryState{}, tick+1)
 		summary.systemsExecuted++
+		summary.systems = append(summary.systems, report)
 		systemLogger.Info("system executed")
 	}
 
@@ -507,6 +957,20 @@ func (s *basicScheduler) runWorkGroup(ctx context.Context, group *workGroupState
 	return summary, nil
 }
 
+// storeSystemRun saves a system's retry state, and its completion tick+1 when
+// completed is non-zero. A circuit reset applied while the system ran replaced
+// loaded and takes precedence over the new retry state.
+func (s *basicScheduler) storeSystemRun(group *workGroupState, idx int, loaded, retry retryState, completed uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if group.retries[idx] == loaded {
+		group.retries[idx] = retry
+	}
+	if completed != 0 {
+		group.systemRuns[idx] = completed
+	}
+}
+
 func (s *basicScheduler) dispatchAsync(ctx context.Context, group *workGroupState, world *World, dt time.Duration, tick uint64, logger Logger, tracer Tracer) *jobHandle {
 	pool := s.asyncPool
 	if pool == nil {
@@ -525,22 +989,40 @@ func (s *basicScheduler) dispatchAsync(ctx context.Context, group *workGroupStat
 		return &jobHandle{result: ch}
 	}
 	return pool.Submit(ctx, func(jobCtx context.Context) jobResult {
-		jobBuf := s.pool.Get()
-		defer s.pool.Put(jobBuf)
-		summary, err := s.runWorkGroup(jobCtx, group, world, dt, tick, jobBuf, logger, tracer, true)
-		summaryCopy := summary
-		if err != nil {
-			return jobResult{err: err, summary: &summaryCopy}
-		}
-		commands := jobBuf.Drain()
-		return jobResult{commands: commands, summary: &summaryCopy}
+		return s.runAsyncJob(jobCtx, group, world, dt, tick, logger, tracer)
 	})
 }
 
+// dispatchSpanning starts a SpanTicks group on its own goroutine rather than
+// the worker pool, so a job that outlives the tick never holds a worker that
+// async groups and parallel stages are waiting for.
+func (s *basicScheduler) dispatchSpanning(group *workGroupState, world *World, dt time.Duration, tick uint64, logger Logger, tracer Tracer) *jobHandle {
+	return spawnJob(s.lifetime, func(jobCtx context.Context) jobResult {
+		return s.runAsyncJob(jobCtx, group, world, dt, tick, logger, tracer)
+	})
+}
+
+func (s *basicScheduler) runAsyncJob(ctx context.Context, group *workGroupState, world *World, dt time.Duration, tick uint64, logger Logger, tracer Tracer) jobResult {
+	jobBuf := s.pool.Get()
+	defer s.pool.Put(jobBuf)
+	summary, err := s.runWorkGroup(ctx, group, world, dt, tick, jobBuf, logger, tracer, true)
+	summaryCopy := summary
+	if err != nil {
+		return jobResult{err: err, summary: &summaryCopy}
+	}
+	commands := jobBuf.Drain()
+	return jobResult{commands: commands, summary: &summaryCopy}
+}
+
 func (s *basicScheduler) publishWorkGroupSummary(summary workGroupRunSummary) {
 	if s.observer == nil {
 		return
 	}
+	if circuits, ok := s.observer.(CircuitObserver); ok {
+		for _, event := range summary.circuits {
+			circuits.SystemCircuitOpened(event)
+		}
+	}
 	s.observer.WorkGroupCompleted(summary.toPublic())
 }
 
@@ -549,6 +1031,7 @@ type workGroupRunSummary struct {
 	mode            WorkGroupMode
 	async           bool
 	tick            uint64
+	staleness       uint64
 	componentReads  []ComponentType
 	componentWrites []ComponentType
 	resourceReads   []string
@@ -558,6 +1041,8 @@ type workGroupRunSummary struct {
 	systemsSkipped  int
 	duration        time.Duration
 	err             error
+	systems         []SystemSummary
+	circuits        []CircuitEvent
 }
 
 func (summary workGroupRunSummary) toPublic() WorkGroupSummary {
@@ -566,6 +1051,7 @@ func (summary workGroupRunSummary) toPublic() WorkGroupSummary {
 		Mode:            summary.mode,
 		Async:           summary.async,
 		Tick:            summary.tick,
+		Staleness:       summary.staleness,
 		Duration:        summary.duration,
 		SystemsTotal:    summary.systemsTotal,
 		SystemsExecuted: summary.systemsExecuted,
@@ -575,6 +1061,7 @@ func (summary workGroupRunSummary) toPublic() WorkGroupSummary {
 		ComponentWrites: append([]ComponentType(nil), summary.componentWrites...),
 		ResourceReads:   append([]string(nil), summary.resourceReads...),
 		ResourceWrites:  append([]string(nil), summary.resourceWrites...),
+		Systems:         append([]SystemSummary(nil), summary.systems...),
 	}
 }
 
@@ -645,9 +1132,16 @@ type systemExecutionContext struct {
 	world    *World
 	dt       time.Duration
 	tick     uint64
+	lastRun  uint64 // tick+1 of the system's previous run, 0 if never
 	logger   Logger
 	tracer   Tracer
 	commands *CommandBuffer
+	group    WorkGroupID
+	system   string
+	observer CommandObserver
+
+	eventsMu sync.Mutex
+	events   []func() // emits staged until the running system completes
 }
 
 func (c *systemExecutionContext) World() *World { return c.world }
@@ -656,11 +1150,53 @@ func (c *systemExecutionContext) TimeDelta() time.Duration { return c.dt }
 
 func (c *systemExecutionContext) TickIndex() uint64 { return c.tick }
 
+func (c *systemExecutionContext) LastRunTick() (uint64, bool) {
+	if c.lastRun == 0 {
+		return 0, false
+	}
+	return c.lastRun - 1, true
+}
+
 func (c *systemExecutionContext) Logger() Logger { return c.logger }
 
+func (c *systemExecutionContext) stageEvent(publish func()) {
+	c.eventsMu.Lock()
+	c.events = append(c.events, publish)
+	c.eventsMu.Unlock()
+}
+
+// takeEvents removes the staged emits, publishing them when publish is set.
+func (c *systemExecutionContext) takeEvents(publish bool) {
+	c.eventsMu.Lock()
+	events := c.events
+	c.events = nil
+	c.eventsMu.Unlock()
+	if publish {
+		for _, emit := range events {
+			emit()
+		}
+	}
+}
+
 func (c *systemExecutionContext) Tracer() Tracer { return c.tracer }
 
-func (c *systemExecutionContext) Defer(cmd Command) { c.commands.Push(cmd) }
+// Defer records the command's provenance so apply errors and the
+// CommandObserver can name the system, work group and tick that queued it.
+func (c *systemExecutionContext) Defer(cmd Command) {
+	if cmd == nil {
+		return
+	}
+	c.commands.Push(&tracedCommand{
+		cmd: cmd,
+		provenance: CommandProvenance{
+			System:    c.system,
+			WorkGroup: c.group,
+			Tick:      c.tick,
+			Caller:    callerLocation(1),
+		},
+		observer: c.observer,
+	})
+}
 
 // noopLogger is used until a real logger is supplied.
 type noopLogger struct{}
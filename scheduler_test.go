@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -398,3 +399,138 @@ func TestSchedulerRetryPolicy(t *testing.T) {
 		t.Fatalf("expected exactly one failure before retry success, got %d", failing.failCount)
 	}
 }
+
+func TestSchedulerSummaryBreaksDownSystems(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	observer := &recordingObserver{}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{Observer: observer})
+
+	spawner := &testSystem{
+		name:      "spawner",
+		failLimit: 1,
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			ctx.Defer(ecs.NewCreateEntityCommand(nil))
+			ctx.Defer(ecs.NewCreateEntityCommand(nil))
+		},
+	}
+	sparse := &testSystem{name: "sparse", desc: ecs.SystemDescriptor{RunEvery: ecs.TickInterval{Every: 2, Offset: 1}}}
+	cfg := ecs.WorkGroupConfig{ID: "mixed", ErrorPolicy: ecs.ErrorPolicyRetry, Systems: []ecs.System{spawner, sparse}}
+	if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.summaries) != 1 || len(observer.summaries[0].Systems) != 2 {
+		t.Fatalf("expected one summary with two systems, got %+v", observer.summaries)
+	}
+	systems := observer.summaries[0].Systems
+	if got := systems[0]; got.Name != "spawner" || got.Status != ecs.SystemStatusExecuted || got.Retries != 1 || got.Commands != 2 || got.Duration <= 0 {
+		t.Fatalf("unexpected spawner summary: %+v", got)
+	}
+	if got := systems[1]; got.Name != "sparse" || got.Status != ecs.SystemStatusSkipped || got.Duration != 0 {
+		t.Fatalf("unexpected sparse summary: %+v", got)
+	}
+}
+
+// barrierSystem blocks until every participant has started, so it only
+// completes when its peers run concurrently.
+func barrierSystem(t *testing.T, name string, writes ecs.ComponentType, barrier *sync.WaitGroup, created *ecs.EntityID) *testSystem {
+	return &testSystem{
+		name: name,
+		desc: ecs.SystemDescriptor{Writes: []ecs.ComponentType{writes}},
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			barrier.Done()
+			done := make(chan struct{})
+			go func() {
+				barrier.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Errorf("%s: peers did not run concurrently", name)
+			}
+			ctx.Defer(ecs.NewCreateEntityCommand(created))
+		},
+	}
+}
+
+func TestSchedulerRunsIndependentGroupsInParallel(t *testing.T) {
+	world := ecs.NewWorld()
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	scheduler.Builder().WithAsyncWorkers(2).WithParallelGroups(true)
+
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	var first, second ecs.EntityID
+	groups := []ecs.WorkGroupConfig{
+		{ID: "a", Systems: []ecs.System{barrierSystem(t, "a", "A", &barrier, &first)}},
+		{ID: "b", Systems: []ecs.System{barrierSystem(t, "b", "B", &barrier, &second)}},
+	}
+	for _, cfg := range groups {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	// Commands merge in declared order regardless of which group finished first.
+	if first.IsZero() || second.IsZero() || first.Index() > second.Index() {
+		t.Fatalf("expected commands applied in declared order: a=%v b=%v", first, second)
+	}
+}
+
+func TestSchedulerSerializesConflictingParallelGroups(t *testing.T) {
+	world := ecs.NewWorld()
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	scheduler.Builder().WithAsyncWorkers(4).WithParallelGroups(true)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	track := func(ecs.ExecutionContext) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}
+	order := make([]string, 0)
+	groups := []ecs.WorkGroupConfig{
+		{ID: "writer", Systems: []ecs.System{&testSystem{name: "writer", executed: &order, deferCmd: track, desc: ecs.SystemDescriptor{Writes: []ecs.ComponentType{"Position"}}}}},
+		{ID: "reader", Systems: []ecs.System{&testSystem{name: "reader", executed: &order, deferCmd: track, desc: ecs.SystemDescriptor{Reads: []ecs.ComponentType{"Position"}}}}},
+		{ID: "late", After: []string{"reader"}, Systems: []ecs.System{&testSystem{name: "late", executed: &order, deferCmd: track}}},
+	}
+	for _, cfg := range groups {
+		if _, err := scheduler.RegisterWorkGroup(cfg); err != nil {
+			t.Fatalf("register %s: %v", cfg.ID, err)
+		}
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if peak != 1 {
+		t.Fatalf("expected conflicting groups to run one at a time, peak=%d", peak)
+	}
+	if strings.Join(order, ",") != "writer,reader,late" {
+		t.Fatalf("unexpected order: %v", order)
+	}
+}
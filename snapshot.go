@@ -0,0 +1,591 @@
+package ecs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// SnapshotFormatVersion is the binary layout version written by World.Snapshot.
+// Restore rejects snapshots written by newer layouts. Version 2 added entity
+// hierarchy links.
+const SnapshotFormatVersion uint16 = 2
+
+// snapshotMagic prefixes every snapshot so foreign input fails fast.
+var snapshotMagic = [8]byte{'E', 'C', 'S', 'S', 'N', 'A', 'P', 0}
+
+const (
+	manifestKindComponent byte = iota
+	manifestKindResource
+)
+
+// Codec serializes component or resource values for snapshots. Version is
+// recorded in the snapshot manifest and handed back to Decode, letting a codec
+// migrate data written by an older version of its type.
+type Codec interface {
+	Version() uint32
+	Encode(value any) ([]byte, error)
+	Decode(version uint32, data []byte) (any, error)
+}
+
+// GobCodec returns a version 1 codec that encodes values of type T with
+// encoding/gob. Decoding data recorded under another version fails.
+func GobCodec[T any]() Codec {
+	return gobCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Version() uint32 { return 1 }
+
+func (gobCodec[T]) Encode(value any) ([]byte, error) {
+	typed, ok := value.(T)
+	if !ok {
+		return nil, fmt.Errorf("%w: gob codec expects %s, got %T", ErrComponentTypeMismatch, typeOf[T](), value)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&typed); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec[T]) Decode(version uint32, data []byte) (any, error) {
+	if version != 1 {
+		return nil, fmt.Errorf("%w: gob codec cannot decode version %d", ErrSnapshotVersion, version)
+	}
+	var value T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// snapshotCodecs holds the codecs registered for a world.
+type snapshotCodecs struct {
+	mu         sync.RWMutex
+	components map[ComponentType]Codec
+	resources  map[string]Codec
+}
+
+// RegisterComponentCodec associates a codec with a registered component so it
+// can be captured by Snapshot. Every registered component needs a codec before
+// the world can be snapshotted.
+func (w *World) RegisterComponentCodec(t ComponentType, codec Codec) error {
+	if codec == nil {
+		return fmt.Errorf("%w: nil codec for component %s", ErrCodecNotRegistered, t)
+	}
+	if _, err := w.storage.View(t); err != nil {
+		return err
+	}
+	w.codecs.mu.Lock()
+	defer w.codecs.mu.Unlock()
+	if w.codecs.components == nil {
+		w.codecs.components = make(map[ComponentType]Codec)
+	}
+	w.codecs.components[t] = codec
+	return nil
+}
+
+// RegisterResourceCodec opts a resource into snapshots. Resources without a
+// codec are left untouched by Snapshot and Restore.
+func (w *World) RegisterResourceCodec(name string, codec Codec) error {
+	if codec == nil {
+		return fmt.Errorf("%w: nil codec for resource %s", ErrCodecNotRegistered, name)
+	}
+	w.codecs.mu.Lock()
+	defer w.codecs.mu.Unlock()
+	if w.codecs.resources == nil {
+		w.codecs.resources = make(map[string]Codec)
+	}
+	w.codecs.resources[name] = codec
+	return nil
+}
+
+type manifestEntry struct {
+	kind    byte
+	name    string
+	version uint32
+	codec   Codec
+}
+
+func (w *World) snapshotManifest() ([]manifestEntry, error) {
+	w.codecs.mu.RLock()
+	defer w.codecs.mu.RUnlock()
+
+	var manifest []manifestEntry
+	for _, t := range w.componentTypes() {
+		codec, ok := w.codecs.components[t]
+		if !ok {
+			return nil, fmt.Errorf("%w: component %s", ErrCodecNotRegistered, t)
+		}
+		manifest = append(manifest, manifestEntry{kind: manifestKindComponent, name: string(t), version: codec.Version(), codec: codec})
+	}
+	names := make([]string, 0, len(w.codecs.resources))
+	for name := range w.codecs.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		codec := w.codecs.resources[name]
+		manifest = append(manifest, manifestEntry{kind: manifestKindResource, name: name, version: codec.Version(), codec: codec})
+	}
+	return manifest, nil
+}
+
+// Snapshot writes the entity registry, entity hierarchy, every component store and opted-in
+// resources to out. Entries are written in a deterministic order so identical
+// worlds produce identical bytes. It must not run concurrently with command
+// application.
+func (w *World) Snapshot(out io.Writer) error {
+	manifest, err := w.snapshotManifest()
+	if err != nil {
+		return err
+	}
+
+	enc := &snapshotEncoder{}
+	enc.buf.Write(snapshotMagic[:])
+	enc.u16(SnapshotFormatVersion)
+
+	enc.u32(uint32(len(manifest)))
+	for _, entry := range manifest {
+		enc.u8(entry.kind)
+		enc.str(entry.name)
+		enc.u32(entry.version)
+	}
+
+	generations, free, alive := w.registry.exportState()
+	enc.u32s(generations)
+	enc.u32s(free)
+	enc.u32(alive)
+
+	links := w.hierarchy.export()
+	enc.u32(uint32(len(links)))
+	for _, link := range links {
+		enc.entity(link.parent)
+		enc.u32(uint32(len(link.children)))
+		for _, child := range link.children {
+			enc.entity(child)
+		}
+	}
+
+	for _, entry := range manifest {
+		switch entry.kind {
+		case manifestKindComponent:
+			if err := w.encodeComponent(enc, ComponentType(entry.name), entry.codec); err != nil {
+				return err
+			}
+		case manifestKindResource:
+			value, ok := w.resources.Get(entry.name)
+			if !ok {
+				enc.u8(0)
+				continue
+			}
+			data, err := entry.codec.Encode(value)
+			if err != nil {
+				return fmt.Errorf("ecs: snapshot resource %s: %w", entry.name, err)
+			}
+			enc.u8(1)
+			enc.bytes(data)
+		}
+	}
+
+	_, err = out.Write(enc.buf.Bytes())
+	return err
+}
+
+func (w *World) encodeComponent(enc *snapshotEncoder, t ComponentType, codec Codec) error {
+	view, err := w.storage.View(t)
+	if err != nil {
+		return err
+	}
+	type entry struct {
+		id    EntityID
+		value any
+	}
+	entries := make([]entry, 0, view.Len())
+	view.Iterate(func(id EntityID, value any) bool {
+		entries = append(entries, entry{id: id, value: value})
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].id.index < entries[j].id.index })
+
+	enc.u32(uint32(len(entries)))
+	for _, e := range entries {
+		data, err := codec.Encode(e.value)
+		if err != nil {
+			return fmt.Errorf("ecs: snapshot component %s for %v: %w", t, e.id, err)
+		}
+		enc.u32(e.id.index)
+		enc.u32(e.id.generation)
+		enc.bytes(data)
+	}
+	return nil
+}
+
+type restoredComponent struct {
+	ids    []EntityID
+	values []any
+}
+
+// Restore replaces the world state with a snapshot produced by Snapshot. The
+// whole stream is decoded and validated before the world is modified, so a
+// failed restore leaves the world unchanged. Every component in the snapshot
+// must be registered with a codec; registered components absent from the
+// snapshot are cleared. Restored components count as added at the current
+// change tick.
+func (w *World) Restore(in io.Reader) error {
+	dec := &snapshotDecoder{r: bufio.NewReader(in)}
+
+	var magic [8]byte
+	dec.read(magic[:])
+	if dec.err != nil || magic != snapshotMagic {
+		return fmt.Errorf("%w: missing snapshot header", ErrSnapshotInvalid)
+	}
+	version := dec.u16()
+	if dec.err != nil {
+		return dec.err
+	}
+	if version == 0 || version > SnapshotFormatVersion {
+		return fmt.Errorf("%w: snapshot format %d, supported up to %d", ErrSnapshotVersion, version, SnapshotFormatVersion)
+	}
+
+	count := dec.u32()
+	manifest := make([]manifestEntry, 0, min(int(count), 1024))
+	w.codecs.mu.RLock()
+	for i := uint32(0); i < count && dec.err == nil; i++ {
+		entry := manifestEntry{kind: dec.u8(), name: dec.str(), version: dec.u32()}
+		switch entry.kind {
+		case manifestKindComponent:
+			entry.codec = w.codecs.components[ComponentType(entry.name)]
+		case manifestKindResource:
+			entry.codec = w.codecs.resources[entry.name]
+		default:
+			dec.fail(fmt.Errorf("%w: unknown manifest kind %d", ErrSnapshotInvalid, entry.kind))
+		}
+		if dec.err == nil && entry.codec == nil {
+			dec.fail(fmt.Errorf("%w: no codec registered for %s", ErrSnapshotManifest, entry.name))
+		}
+		manifest = append(manifest, entry)
+	}
+	w.codecs.mu.RUnlock()
+
+	generations := dec.u32s()
+	free := dec.u32s()
+	alive := dec.u32()
+	if dec.err == nil && int(alive)+len(free) != len(generations) {
+		dec.fail(fmt.Errorf("%w: registry counts disagree", ErrSnapshotInvalid))
+	}
+	// Create issues odd generations and Destroy moves a slot to the next even
+	// one, so a freed slot can never match a handle that was handed out.
+	freed := make(map[uint32]struct{}, len(free))
+	for _, idx := range free {
+		if _, dup := freed[idx]; dup || int(idx) >= len(generations) || generations[idx]%2 != 0 {
+			dec.fail(fmt.Errorf("%w: bad free slot %d", ErrSnapshotInvalid, idx))
+			break
+		}
+		freed[idx] = struct{}{}
+	}
+	live := func(id EntityID) bool {
+		_, dead := freed[id.index]
+		return !dead && int(id.index) < len(generations) && generations[id.index] == id.generation
+	}
+
+	var links []hierarchyLinks
+	if version >= 2 && dec.err == nil {
+		links = dec.hierarchy(live)
+	}
+
+	components := make(map[ComponentType]restoredComponent)
+	resources := make(map[string]any)
+	present := make(map[string]bool)
+	for _, entry := range manifest {
+		if dec.err != nil {
+			break
+		}
+		switch entry.kind {
+		case manifestKindComponent:
+			t := ComponentType(entry.name)
+			if _, err := w.storage.View(t); err != nil {
+				dec.fail(fmt.Errorf("%w: component %s: %v", ErrSnapshotManifest, t, err))
+				break
+			}
+			n := dec.u32()
+			restored := restoredComponent{}
+			for i := uint32(0); i < n && dec.err == nil; i++ {
+				id := EntityID{index: dec.u32(), generation: dec.u32()}
+				data := dec.bytes()
+				if dec.err != nil {
+					break
+				}
+				if !live(id) {
+					dec.fail(fmt.Errorf("%w: component %s references dead entity %v", ErrSnapshotInvalid, t, id))
+					break
+				}
+				value, err := entry.codec.Decode(entry.version, data)
+				if err != nil {
+					dec.fail(fmt.Errorf("ecs: restore component %s for %v: %w", t, id, err))
+					break
+				}
+				restored.ids = append(restored.ids, id)
+				restored.values = append(restored.values, value)
+			}
+			components[t] = restored
+		case manifestKindResource:
+			if dec.u8() == 0 {
+				present[entry.name] = false
+				continue
+			}
+			data := dec.bytes()
+			if dec.err != nil {
+				break
+			}
+			value, err := entry.codec.Decode(entry.version, data)
+			if err != nil {
+				dec.fail(fmt.Errorf("ecs: restore resource %s: %w", entry.name, err))
+				break
+			}
+			resources[entry.name] = value
+			present[entry.name] = true
+		}
+	}
+	if dec.err != nil {
+		return dec.err
+	}
+
+	types := w.componentTypes()
+	stores := make([]ComponentStore, len(types))
+	for i, t := range types {
+		store, err := w.componentStore(t)
+		if err != nil {
+			return err
+		}
+		stores[i] = store
+	}
+
+	// Stores may still reject a value, so record the previous state and undo
+	// it on failure; a failed Restore leaves the world unchanged.
+	undo := &applyJournal{}
+	oldGenerations, oldFree, oldAlive := w.registry.exportState()
+	undo.add(func() { w.registry.importState(oldGenerations, oldFree, oldAlive) })
+	oldLinks := w.hierarchy.export()
+	undo.add(func() { w.hierarchy.replace(oldLinks) })
+	w.registry.importState(generations, free, alive)
+	w.hierarchy.replace(links)
+	for n, t := range types {
+		store := stores[n]
+		undo.add(saveStore(store))
+		undo.add(w.changes.saveType(t))
+		store.Clear()
+		w.changes.clear(t)
+		restored := components[t]
+		for i, id := range restored.ids {
+			if err := store.Set(id, restored.values[i]); err != nil {
+				undo.rollback()
+				return fmt.Errorf("ecs: restore component %s for %v: %w", t, id, err)
+			}
+			w.changes.stamp(t, id)
+		}
+	}
+	for name, ok := range present {
+		if ok {
+			w.resources.Set(name, resources[name])
+		} else {
+			w.resources.Delete(name)
+		}
+	}
+	return nil
+}
+
+// saveStore captures a store's contents and returns a func restoring them.
+func saveStore(store ComponentStore) func() {
+	var ids []EntityID
+	var values []any
+	store.Iterate(func(id EntityID, value any) bool {
+		ids = append(ids, id)
+		values = append(values, value)
+		return true
+	})
+	return func() {
+		store.Clear()
+		for i, id := range ids {
+			_ = store.Set(id, values[i])
+		}
+	}
+}
+
+type snapshotEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *snapshotEncoder) u8(v byte) {
+	e.buf.WriteByte(v)
+}
+
+func (e *snapshotEncoder) u16(v uint16) {
+	var tmp [2]byte
+	binary.LittleEndian.PutUint16(tmp[:], v)
+	e.buf.Write(tmp[:])
+}
+
+func (e *snapshotEncoder) u32(v uint32) {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	e.buf.Write(tmp[:])
+}
+
+func (e *snapshotEncoder) u64(v uint64) {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	e.buf.Write(tmp[:])
+}
+
+func (e *snapshotEncoder) u32s(vs []uint32) {
+	e.u32(uint32(len(vs)))
+	for _, v := range vs {
+		e.u32(v)
+	}
+}
+
+func (e *snapshotEncoder) entity(id EntityID) {
+	e.u32(id.index)
+	e.u32(id.generation)
+}
+
+func (e *snapshotEncoder) bytes(data []byte) {
+	e.u32(uint32(len(data)))
+	e.buf.Write(data)
+}
+
+func (e *snapshotEncoder) str(s string) {
+	e.bytes([]byte(s))
+}
+
+// snapshotDecoder reads little-endian fields, latching the first error so
+// callers can check once after a sequence of reads.
+type snapshotDecoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+// maxSnapshotChunk caps length prefixes so corrupt input cannot force huge allocations.
+const maxSnapshotChunk = 1 << 30
+
+func (d *snapshotDecoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *snapshotDecoder) read(p []byte) {
+	if d.err != nil {
+		return
+	}
+	if _, err := io.ReadFull(d.r, p); err != nil {
+		d.fail(fmt.Errorf("%w: %v", ErrSnapshotInvalid, err))
+	}
+}
+
+func (d *snapshotDecoder) u8() byte {
+	var tmp [1]byte
+	d.read(tmp[:])
+	return tmp[0]
+}
+
+func (d *snapshotDecoder) u16() uint16 {
+	var tmp [2]byte
+	d.read(tmp[:])
+	return binary.LittleEndian.Uint16(tmp[:])
+}
+
+func (d *snapshotDecoder) u32() uint32 {
+	var tmp [4]byte
+	d.read(tmp[:])
+	return binary.LittleEndian.Uint32(tmp[:])
+}
+
+func (d *snapshotDecoder) u64() uint64 {
+	var tmp [8]byte
+	d.read(tmp[:])
+	return binary.LittleEndian.Uint64(tmp[:])
+}
+
+func (d *snapshotDecoder) length() int {
+	n := d.u32()
+	if n > maxSnapshotChunk {
+		d.fail(fmt.Errorf("%w: length %d exceeds limit", ErrSnapshotInvalid, n))
+		return 0
+	}
+	return int(n)
+}
+
+func (d *snapshotDecoder) u32s() []uint32 {
+	n := d.length()
+	if d.err != nil {
+		return nil
+	}
+	out := make([]uint32, 0, min(n, 1<<16))
+	for i := 0; i < n && d.err == nil; i++ {
+		out = append(out, d.u32())
+	}
+	return out
+}
+
+func (d *snapshotDecoder) bytes() []byte {
+	n := d.length()
+	if d.err != nil {
+		return nil
+	}
+	out := make([]byte, n)
+	d.read(out)
+	return out
+}
+
+func (d *snapshotDecoder) str() string {
+	return string(d.bytes())
+}
+
+func (d *snapshotDecoder) entity() EntityID {
+	return EntityID{index: d.u32(), generation: d.u32()}
+}
+
+// hierarchy decodes parent links, rejecting dead entities, repeated parents or
+// children, and cycles.
+func (d *snapshotDecoder) hierarchy(live func(EntityID) bool) []hierarchyLinks {
+	n := d.length()
+	links := make([]hierarchyLinks, 0, min(n, 1024))
+	parents := make(map[EntityID]EntityID)
+	seen := make(map[EntityID]bool)
+	for i := 0; i < n && d.err == nil; i++ {
+		link := hierarchyLinks{parent: d.entity()}
+		count := d.length()
+		for j := 0; j < count && d.err == nil; j++ {
+			child := d.entity()
+			if _, dup := parents[child]; dup || !live(child) || child == link.parent {
+				d.fail(fmt.Errorf("%w: bad hierarchy link %v -> %v", ErrSnapshotInvalid, link.parent, child))
+				break
+			}
+			parents[child] = link.parent
+			link.children = append(link.children, child)
+		}
+		if d.err == nil && (seen[link.parent] || !live(link.parent) || len(link.children) == 0) {
+			d.fail(fmt.Errorf("%w: bad hierarchy parent %v", ErrSnapshotInvalid, link.parent))
+		}
+		seen[link.parent] = true
+		links = append(links, link)
+	}
+	for child := range parents {
+		steps := 0
+		for at, ok := parents[child]; ok && d.err == nil; at, ok = parents[at] {
+			if steps++; steps > len(parents) {
+				d.fail(fmt.Errorf("%w: hierarchy cycle through %v", ErrSnapshotInvalid, child))
+			}
+		}
+	}
+	return links
+}
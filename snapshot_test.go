@@ -0,0 +1,239 @@
+package ecs_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+type snapshotPosition struct{ X, Y float64 }
+
+func newSnapshotWorld(t *testing.T) *ecs.World {
+	t.Helper()
+	world := ecs.NewWorld()
+	if _, err := ecs.RegisterComponent[snapshotPosition](world, "Position", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register position: %v", err)
+	}
+	if err := world.RegisterComponentCodec("Position", ecs.GobCodec[snapshotPosition]()); err != nil {
+		t.Fatalf("register codec: %v", err)
+	}
+	if err := world.RegisterComponent("Name", ecsstorage.NewSharedStrategy()); err != nil {
+		t.Fatalf("register name: %v", err)
+	}
+	if err := world.RegisterComponentCodec("Name", ecs.GobCodec[string]()); err != nil {
+		t.Fatalf("register codec: %v", err)
+	}
+	if err := world.RegisterResourceCodec("round", ecs.GobCodec[int]()); err != nil {
+		t.Fatalf("register resource codec: %v", err)
+	}
+	return world
+}
+
+func TestWorldSnapshotRestoreRoundTrip(t *testing.T) {
+	src := newSnapshotWorld(t)
+	a := src.Registry().Create()
+	b := src.Registry().Create()
+	dead := src.Registry().Create()
+	if err := src.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(a, "Position", snapshotPosition{X: 1, Y: 2}),
+		ecs.NewAddComponentCommand(b, "Name", "goblin"),
+		ecs.NewDestroyEntityCommand(dead),
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	src.Resources().Set("round", 3)
+	src.Resources().Set("transient", "not persisted")
+
+	var buf bytes.Buffer
+	if err := src.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	dst := newSnapshotWorld(t)
+	dst.Registry().Create() // pre-existing state must be replaced by the restore
+	dst.Registry().Create()
+	dst.Registry().Create()
+	dst.Registry().Create()
+	if err := dst.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	if !dst.Registry().IsAlive(a) || !dst.Registry().IsAlive(b) {
+		t.Fatalf("expected snapshot entities to be alive")
+	}
+	if dst.Registry().IsAlive(dead) {
+		t.Fatalf("destroyed entity must stay dead")
+	}
+	if dst.Registry().Count() != 2 {
+		t.Fatalf("expected 2 live entities, got %d", dst.Registry().Count())
+	}
+
+	var again bytes.Buffer
+	if err := dst.Snapshot(&again); err != nil {
+		t.Fatalf("second snapshot: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Fatalf("expected deterministic snapshot bytes")
+	}
+	recycled := dst.Registry().Create()
+	if recycled.Index() != dead.Index() || recycled.Generation() == dead.Generation() {
+		t.Fatalf("expected free list to survive restore, got %v (dead %v)", recycled, dead)
+	}
+
+	pos, _ := ecs.LookupComponent[snapshotPosition](dst, "Position")
+	if got, ok := pos.Get(dst, a); !ok || got != (snapshotPosition{X: 1, Y: 2}) {
+		t.Fatalf("unexpected position after restore: %+v ok=%v", got, ok)
+	}
+	names, _ := dst.ViewComponent("Name")
+	if got, ok := names.Get(b); !ok || got.(string) != "goblin" {
+		t.Fatalf("unexpected name after restore: %v ok=%v", got, ok)
+	}
+	if round, ok := dst.Resources().Get("round"); !ok || round.(int) != 3 {
+		t.Fatalf("expected opted-in resource to restore, got %v ok=%v", round, ok)
+	}
+	if _, ok := dst.Resources().Get("transient"); ok {
+		t.Fatalf("resource without codec must not be restored")
+	}
+}
+
+func TestWorldSnapshotRequiresCodecs(t *testing.T) {
+	world := ecs.NewWorld()
+	if err := world.RegisterComponent("Opaque", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := world.Snapshot(&bytes.Buffer{}); !errors.Is(err, ecs.ErrCodecNotRegistered) {
+		t.Fatalf("expected ErrCodecNotRegistered, got %v", err)
+	}
+}
+
+func TestWorldRestoreFailsLoudly(t *testing.T) {
+	src := newSnapshotWorld(t)
+	var buf bytes.Buffer
+	if err := src.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	data := buf.Bytes()
+
+	if err := newSnapshotWorld(t).Restore(bytes.NewReader([]byte("garbage"))); !errors.Is(err, ecs.ErrSnapshotInvalid) {
+		t.Fatalf("expected ErrSnapshotInvalid, got %v", err)
+	}
+
+	future := append([]byte(nil), data...)
+	future[8] = 0xff
+	if err := newSnapshotWorld(t).Restore(bytes.NewReader(future)); !errors.Is(err, ecs.ErrSnapshotVersion) {
+		t.Fatalf("expected ErrSnapshotVersion, got %v", err)
+	}
+
+	missing := ecs.NewWorld()
+	if err := missing.RegisterComponent("Position", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := missing.Restore(bytes.NewReader(data)); !errors.Is(err, ecs.ErrSnapshotManifest) {
+		t.Fatalf("expected ErrSnapshotManifest, got %v", err)
+	}
+
+	if err := newSnapshotWorld(t).Restore(bytes.NewReader(data[:len(data)-1])); !errors.Is(err, ecs.ErrSnapshotInvalid) {
+		t.Fatalf("expected truncated snapshot to fail, got %v", err)
+	}
+}
+
+func TestWorldRestoreRejectsBadFreeList(t *testing.T) {
+	src := ecs.NewWorld()
+	for range 3 {
+		src.Registry().Create()
+	}
+	src.Registry().Destroy(ecs.EntityIDFromParts(1, 1))
+	src.Registry().Destroy(ecs.EntityIDFromParts(2, 1))
+	var buf bytes.Buffer
+	if err := src.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// The registry follows an empty manifest: generations [1 2 2] at offset
+	// 14, then the free list [1 2] whose second entry sits at offset 38.
+	for name, slot := range map[string]byte{"duplicate": 1, "out of range": 7, "live generation": 0} {
+		data := bytes.Clone(buf.Bytes())
+		data[38] = slot
+		if err := ecs.NewWorld().Restore(bytes.NewReader(data)); !errors.Is(err, ecs.ErrSnapshotInvalid) {
+			t.Fatalf("%s free slot: expected ErrSnapshotInvalid, got %v", name, err)
+		}
+	}
+}
+
+// rejectingStrategy builds stores whose Set fails for negative values.
+type rejectingStrategy struct{}
+
+func (rejectingStrategy) Name() string { return "rejecting" }
+
+func (rejectingStrategy) NewStore(t ecs.ComponentType) ecs.ComponentStore {
+	return &rejectingStore{ComponentStore: ecsstorage.NewDenseStrategy().NewStore(t)}
+}
+
+type rejectingStore struct {
+	ecs.ComponentStore
+}
+
+func (s *rejectingStore) Set(id ecs.EntityID, value any) error {
+	if value.(int) < 0 {
+		return errors.New("negative value")
+	}
+	return s.ComponentStore.Set(id, value)
+}
+
+func TestWorldRestoreFailureLeavesWorldUnchanged(t *testing.T) {
+	newWorld := func() *ecs.World {
+		world := ecs.NewWorld()
+		if err := world.RegisterComponent("Score", rejectingStrategy{}); err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		if err := world.RegisterComponentCodec("Score", ecs.GobCodec[int]()); err != nil {
+			t.Fatalf("register codec: %v", err)
+		}
+		return world
+	}
+	src := newWorld()
+	var ids []ecs.EntityID
+	for range 3 {
+		ids = append(ids, src.Registry().Create())
+	}
+	// Write the rejected value straight into the wrapped store.
+	scores, _ := src.ViewComponent("Score")
+	_ = scores.(*rejectingStore).ComponentStore.Set(ids[2], -1)
+	var buf bytes.Buffer
+	if err := src.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	dst := newWorld()
+	parent := dst.Registry().Create()
+	child := dst.Registry().Create()
+	err := dst.ApplyCommands([]ecs.Command{
+		ecs.NewAddComponentCommand(parent, "Score", 7),
+		ecs.NewSetParentCommand(child, parent),
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	before, _ := dst.ComponentTicks("Score", parent)
+	if err := dst.Restore(bytes.NewReader(buf.Bytes())); err == nil || !strings.Contains(err.Error(), "negative value") {
+		t.Fatalf("expected the store to reject the restore, got %v", err)
+	}
+
+	view, _ := dst.ViewComponent("Score")
+	if got, ok := view.Get(parent); !ok || got.(int) != 7 || view.Len() != 1 {
+		t.Fatalf("expected Score to be untouched, got %v ok=%v len=%d", got, ok, view.Len())
+	}
+	if ticks, ok := dst.ComponentTicks("Score", parent); !ok || ticks != before {
+		t.Fatalf("expected change ticks to be untouched, got %+v", ticks)
+	}
+	if dst.Registry().Count() != 2 || !dst.Registry().IsAlive(child) {
+		t.Fatalf("expected registry to be untouched")
+	}
+	if got, ok := dst.Parent(child); !ok || got != parent {
+		t.Fatalf("expected hierarchy to be untouched, got %v ok=%v", got, ok)
+	}
+}
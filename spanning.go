@@ -0,0 +1,202 @@
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// spanningJob tracks a SpanTicks group dispatched on an earlier tick.
+type spanningJob struct {
+	handle  *jobHandle
+	started uint64
+}
+
+// finishSpanning publishes a completed SpanTicks run and applies its commands
+// at the boundary of tick, honouring the group's error and shutdown policies.
+func (s *basicScheduler) finishSpanning(world *World, group *workGroupState, res jobResult, started, tick uint64, logger Logger) error {
+	if summary := res.Summary(); summary != nil {
+		summary.staleness = tick - started
+		s.publishWorkGroupSummary(*summary)
+	}
+	if err := res.Err(); err != nil {
+		if group.policy == ErrorPolicyContinue {
+			logger.Error("async work group error", "group", string(group.id), "started", started, "err", err)
+			return nil
+		}
+		return err
+	}
+	commands := res.Commands()
+	if len(commands) == 0 {
+		return nil
+	}
+	if s.discardSpanningCommands(group) {
+		logger.Info("discarding in-flight async commands", "group", string(group.id), "commands", len(commands))
+		return nil
+	}
+	return world.ApplyCommands(commands)
+}
+
+// retire finishes the SpanTicks job of an unregistered group and only then
+// runs the group's shutdown callbacks, so they never overlap its systems.
+func (s *basicScheduler) retire(ctx context.Context, world *World, group *workGroupState, res jobResult, tick uint64, logger Logger) error {
+	started := group.inflight.started
+	group.inflight = nil
+	s.mu.Lock()
+	s.retiring = slices.DeleteFunc(s.retiring, func(g *workGroupState) bool { return g == group })
+	s.mu.Unlock()
+	return errors.Join(
+		s.finishSpanning(world, group, res, started, tick, logger),
+		shutdownSystems(ctx, group, world),
+	)
+}
+
+// drainSpanning waits for every in-flight SpanTicks group during shutdown,
+// retiring those already unregistered. When ctx ends first the jobs are
+// cancelled and the wait can be retried.
+func (s *basicScheduler) drainSpanning(ctx context.Context) error {
+	s.mu.RLock()
+	groups := append([]*workGroupState(nil), s.orderedGroups...)
+	groups = append(groups, s.detached...)
+	retiring := append([]*workGroupState(nil), s.retiring...)
+	world := s.world
+	logger := s.logger
+	next := s.tickIndex
+	s.mu.RUnlock()
+
+	// Apply as if at the boundary after the last completed tick.
+	world.changes.setTick(next)
+	tick := next
+	if tick > 0 {
+		tick--
+	}
+	for _, group := range groups {
+		if group.inflight == nil {
+			continue
+		}
+		res, err := group.inflight.handle.WaitContext(ctx)
+		if err != nil {
+			s.stopSpanning()
+			return err
+		}
+		started := group.inflight.started
+		group.inflight = nil
+		if err := s.finishSpanning(world, group, res, started, tick, logger); err != nil {
+			return err
+		}
+	}
+	var errs []error
+	for _, group := range retiring {
+		res, err := group.inflight.handle.WaitContext(ctx)
+		if err != nil {
+			s.stopSpanning()
+			return errors.Join(append(errs, err)...)
+		}
+		errs = append(errs, s.retire(ctx, world, group, res, tick, logger))
+	}
+	return errors.Join(errs...)
+}
+
+// spanningView returns the world a SpanTicks job runs against. The job keeps
+// reading while later ticks apply commands, so the components its systems
+// declare as reads are copied, along with their change ticks, the registry and
+// the hierarchy. Resources and event queues synchronise themselves and are
+// shared.
+func (w *World) spanningView(reads map[ComponentType]struct{}) *World {
+	w.applyMu.RLock()
+	defer w.applyMu.RUnlock()
+	frozen := make(frozenProvider, len(reads))
+	view := &World{
+		registry:  NewEntityRegistry(),
+		storage:   frozen,
+		resources: w.resources,
+	}
+	view.registry.importState(w.registry.exportState())
+	view.hierarchy.replace(w.hierarchy.export())
+	view.changes.tick = w.changes.currentTick()
+	view.changes.entries = make(map[ComponentType]map[uint32]trackedTicks, len(reads))
+	w.types.mu.RLock()
+	view.types.types = maps.Clone(w.types.types)
+	w.types.mu.RUnlock()
+	w.events.mu.RLock()
+	view.events.channels = maps.Clone(w.events.channels)
+	w.events.mu.RUnlock()
+	for t := range reads {
+		src, err := w.storage.View(t)
+		if err != nil {
+			continue
+		}
+		frozen[t] = freezeView(src)
+		view.changes.entries[t] = w.changes.clone(t)
+	}
+	return view
+}
+
+// frozenProvider serves the component copies of a spanningView.
+type frozenProvider map[ComponentType]*frozenView
+
+func (p frozenProvider) RegisterComponent(ComponentType, StorageStrategy) error {
+	return fmt.Errorf("ecs: SpanTicks world view is read-only")
+}
+
+func (p frozenProvider) View(t ComponentType) (ComponentView, error) {
+	if view, ok := p[t]; ok {
+		return view, nil
+	}
+	return nil, fmt.Errorf("%w: SpanTicks group does not read %s", ErrQueryAccessUndeclared, t)
+}
+
+func (p frozenProvider) Apply(*World, []Command) error {
+	return fmt.Errorf("ecs: SpanTicks world view is read-only")
+}
+
+// frozenView is an immutable copy of a component view.
+type frozenView struct {
+	typ    ComponentType
+	ids    []EntityID
+	values []any
+	index  map[EntityID]int
+}
+
+func freezeView(src ComponentView) *frozenView {
+	view := &frozenView{typ: src.ComponentType(), index: make(map[EntityID]int, src.Len())}
+	src.Iterate(func(id EntityID, value any) bool {
+		view.index[id] = len(view.ids)
+		view.ids = append(view.ids, id)
+		view.values = append(view.values, value)
+		return true
+	})
+	return view
+}
+
+func (v *frozenView) ComponentType() ComponentType { return v.typ }
+
+func (v *frozenView) Len() int { return len(v.ids) }
+
+func (v *frozenView) Has(id EntityID) bool {
+	_, ok := v.index[id]
+	return ok
+}
+
+func (v *frozenView) Get(id EntityID) (any, bool) {
+	i, ok := v.index[id]
+	if !ok {
+		return nil, false
+	}
+	return v.values[i], true
+}
+
+func (v *frozenView) Iterate(fn func(EntityID, any) bool) {
+	for i, id := range v.ids {
+		if !fn(id, v.values[i]) {
+			return
+		}
+	}
+}
+
+var (
+	_ StorageProvider = frozenProvider(nil)
+	_ ComponentView   = (*frozenView)(nil)
+)
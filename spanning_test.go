@@ -0,0 +1,310 @@
+package ecs_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+// slowSystem blocks each run until released and then creates an entity.
+type slowSystem struct {
+	runs    atomic.Int32
+	release chan struct{}
+}
+
+func (s *slowSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: "pathfind", AsyncAllowed: true}
+}
+
+func (s *slowSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	s.runs.Add(1)
+	<-s.release
+	exec.Defer(ecs.NewCreateEntityCommand(nil))
+	return ecs.SystemResult{}
+}
+
+// retiringSystem is a slowSystem that notes whether Shutdown overlapped a run.
+type retiringSystem struct {
+	slowSystem
+	active     atomic.Bool
+	overlapped atomic.Bool
+	shutdowns  atomic.Int32
+}
+
+func (s *retiringSystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	s.active.Store(true)
+	defer s.active.Store(false)
+	return s.slowSystem.Run(ctx, exec)
+}
+
+func (s *retiringSystem) Shutdown(context.Context, *ecs.World) error {
+	if s.active.Load() {
+		s.overlapped.Store(true)
+	}
+	s.shutdowns.Add(1)
+	return nil
+}
+
+// scanSystem reads positions once released, long after its dispatch tick.
+type scanSystem struct {
+	position ecs.Component[snapshotPosition]
+	release  chan struct{}
+	started  atomic.Bool
+	seen     []float64
+	err      error
+}
+
+func (s *scanSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: "scan", AsyncAllowed: true, Reads: []ecs.ComponentType{"Position"}}
+}
+
+func (s *scanSystem) Run(_ context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	s.started.Store(true)
+	<-s.release
+	s.err = s.position.Iterate(exec.World(), func(_ ecs.EntityID, pos snapshotPosition) bool {
+		s.seen = append(s.seen, pos.X)
+		return true
+	})
+	_, undeclared := exec.World().ViewComponent("Velocity")
+	if !errors.Is(undeclared, ecs.ErrQueryAccessUndeclared) {
+		s.err = errors.Join(s.err, errors.New("undeclared component was readable"))
+	}
+	return ecs.SystemResult{}
+}
+
+func newSpanningScheduler(t *testing.T, world *ecs.World, sys ecs.System) (ecs.Scheduler, *recordingObserver) {
+	t.Helper()
+	scheduler, err := ecs.NewScheduler(world)
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	observer := &recordingObserver{}
+	scheduler.Builder().WithAsyncWorkers(1).WithInstrumentation(ecs.InstrumentationConfig{Observer: observer})
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "paths", Mode: ecs.WorkGroupModeAsync, SpanTicks: true, Systems: []ecs.System{sys}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return scheduler, observer
+}
+
+func TestSpanningAsyncGroupRunsAcrossTicks(t *testing.T) {
+	world := ecs.NewWorld()
+	slow := &slowSystem{release: make(chan struct{})}
+	scheduler, observer := newSpanningScheduler(t, world, slow)
+	defer scheduler.Shutdown(context.Background())
+
+	// The group stays in flight, so ticks finish without waiting for it and
+	// it is not dispatched again.
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	for slow.runs.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := scheduler.Run(context.Background(), 2, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if runs := slow.runs.Load(); runs != 1 {
+		t.Fatalf("expected a single dispatch while in flight, got %d", runs)
+	}
+	if world.Registry().Count() != 0 {
+		t.Fatalf("commands applied before the group completed")
+	}
+
+	close(slow.release)
+	for tick := 3; world.Registry().Count() == 0; tick++ {
+		if tick > 500 {
+			t.Fatalf("spanning group never completed")
+		}
+		time.Sleep(time.Millisecond)
+		if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+			t.Fatalf("tick: %v", err)
+		}
+	}
+
+	observer.mu.Lock()
+	summary := observer.summaries[0]
+	observer.mu.Unlock()
+	if summary.WorkGroupID != "paths" || summary.Tick != 0 || summary.Staleness < 3 {
+		t.Fatalf("expected a stale summary tagged with the start tick, got tick=%d staleness=%d", summary.Tick, summary.Staleness)
+	}
+}
+
+func TestSpanTicksRequiresAsyncMode(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "sync", SpanTicks: true}); err == nil {
+		t.Fatalf("expected SpanTicks on a synchronized group to be rejected")
+	}
+}
+
+func TestShutdownAppliesInFlightSpanningGroups(t *testing.T) {
+	world := ecs.NewWorld()
+	slow := &slowSystem{release: make(chan struct{})}
+	scheduler, _ := newSpanningScheduler(t, world, slow)
+
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	expired, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	go func() {
+		<-expired.Done()
+		close(slow.release)
+	}()
+	if err := scheduler.Shutdown(expired); err == nil {
+		t.Fatalf("expected shutdown to report the expired context")
+	}
+	if err := scheduler.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if world.Registry().Count() != 1 {
+		t.Fatalf("expected in-flight commands to apply on shutdown, got %d entities", world.Registry().Count())
+	}
+}
+
+func TestSpanningGroupReadsACopyTakenAtDispatch(t *testing.T) {
+	world := ecs.NewWorld()
+	position, err := ecs.RegisterComponent[snapshotPosition](world, "Position", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := world.RegisterComponent("Velocity", ecsstorage.NewDenseStrategy()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	id := world.Registry().Create()
+	if err := position.Set(world, id, snapshotPosition{}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	scan := &scanSystem{position: position, release: make(chan struct{})}
+	scheduler, _ := newSpanningScheduler(t, world, scan)
+	mover := &testSystem{
+		name: "move",
+		desc: ecs.SystemDescriptor{Writes: []ecs.ComponentType{"Position"}},
+		deferCmd: func(ctx ecs.ExecutionContext) {
+			ctx.Defer(position.AddCommand(id, snapshotPosition{X: float64(ctx.TickIndex() + 1)}))
+		},
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "movement", Systems: []ecs.System{mover}}); err != nil {
+		t.Fatalf("register movement: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := scheduler.Run(ctx, 3, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	for !scan.started.Load() {
+		time.Sleep(time.Millisecond)
+	}
+	// Control calls take effect between ticks while the job is still running.
+	if err := scheduler.PauseSystem("paths", "scan"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if err := scheduler.ResetCircuit("paths", "scan"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	close(scan.release)
+	// Writes keep landing while the job reads.
+	if err := scheduler.Run(ctx, 3, time.Millisecond); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if err := scheduler.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if scan.err != nil {
+		t.Fatalf("scan: %v", scan.err)
+	}
+	if len(scan.seen) != 1 || scan.seen[0] != 0 {
+		t.Fatalf("expected the position from the dispatch tick, got %v", scan.seen)
+	}
+	if got, _ := position.Get(world, id); got.X != 6 {
+		t.Fatalf("expected movement to keep running, got %v", got.X)
+	}
+}
+
+func TestUnregisterWaitsForInFlightSpanningGroup(t *testing.T) {
+	for _, policy := range []ecs.ShutdownPolicy{ecs.ShutdownApplyCommands, ecs.ShutdownDiscardCommands} {
+		world := ecs.NewWorld()
+		sys := &retiringSystem{slowSystem: slowSystem{release: make(chan struct{})}}
+		scheduler, _ := newSpanningScheduler(t, world, sys)
+		scheduler.Builder().WithShutdownPolicy(policy)
+		ctx := context.Background()
+
+		if err := scheduler.Tick(ctx, time.Millisecond); err != nil {
+			t.Fatalf("tick: %v", err)
+		}
+		for sys.runs.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if err := scheduler.UnregisterWorkGroup("paths"); err != nil {
+			t.Fatalf("unregister: %v", err)
+		}
+		if err := scheduler.Run(ctx, 2, time.Millisecond); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		if sys.shutdowns.Load() != 0 {
+			t.Fatalf("shutdown ran while the system was still running")
+		}
+
+		close(sys.release)
+		for tick := 0; sys.shutdowns.Load() == 0; tick++ {
+			if tick > 500 {
+				t.Fatalf("retired group never shut down")
+			}
+			time.Sleep(time.Millisecond)
+			if err := scheduler.Tick(ctx, time.Millisecond); err != nil {
+				t.Fatalf("tick: %v", err)
+			}
+		}
+		if sys.overlapped.Load() || sys.runs.Load() != 1 {
+			t.Fatalf("expected one run and no overlapping shutdown, got %d runs overlapped=%t", sys.runs.Load(), sys.overlapped.Load())
+		}
+		want := 1
+		if policy == ecs.ShutdownDiscardCommands {
+			want = 0
+		}
+		if got := world.Registry().Count(); got != want {
+			t.Fatalf("policy %d: expected %d entities from the retired job, got %d", policy, want, got)
+		}
+		if err := scheduler.Shutdown(ctx); err != nil || sys.shutdowns.Load() != 1 {
+			t.Fatalf("expected shutdown to skip the retired group, err=%v shutdowns=%d", err, sys.shutdowns.Load())
+		}
+	}
+}
+
+func TestSpanningGroupsDoNotHoldPoolWorkers(t *testing.T) {
+	world := ecs.NewWorld()
+	slow := &slowSystem{release: make(chan struct{})}
+	// One worker and one spanning group: the async group below still needs a
+	// worker every tick.
+	scheduler, _ := newSpanningScheduler(t, world, slow)
+	var executed []string
+	ui := &testSystem{name: "ui", desc: ecs.SystemDescriptor{AsyncAllowed: true}, executed: &executed}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "ui", Mode: ecs.WorkGroupModeAsync, Systems: []ecs.System{ui}}); err != nil {
+		t.Fatalf("register ui: %v", err)
+	}
+	ctx := context.Background()
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Run(ctx, 4, time.Millisecond) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("ticks stalled behind the in-flight spanning group")
+	}
+	if len(executed) != 4 {
+		t.Fatalf("expected the async group to run every tick, got %d runs", len(executed))
+	}
+	close(slow.release)
+	if err := scheduler.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
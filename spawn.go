@@ -0,0 +1,127 @@
+package ecs
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ComponentValue pairs a component type with the value to store.
+type ComponentValue struct {
+	Type  ComponentType
+	Value any
+}
+
+// Bundle lists the components an entity is spawned with.
+type Bundle []ComponentValue
+
+// With pairs value with the component for use in a Bundle.
+func (c Component[T]) With(value T) ComponentValue {
+	return ComponentValue{Type: c.typ, Value: value}
+}
+
+// NewSpawnCommand enqueues creating an entity together with every component
+// in bundle. If any component cannot be stored the entity is not created.
+// OnAdd hooks fire once all components are in place.
+//
+// The returned placeholder may be passed to other entity commands applied in
+// the same ApplyCommands call, including hook follow-ups, and resolves to the
+// spawned entity. Placeholders never refer to live entities directly.
+func NewSpawnCommand(bundle Bundle) (Command, EntityID) {
+	placeholder := nextPlaceholder()
+	return spawnCommand{placeholder: placeholder, bundle: bundle}, placeholder
+}
+
+// IsPlaceholder reports whether the identifier was returned by NewSpawnCommand
+// and stands in for an entity that does not exist yet.
+func (id EntityID) IsPlaceholder() bool {
+	return id.generation == 0 && id.index != 0
+}
+
+var placeholderSeq atomic.Uint32
+
+// nextPlaceholder issues identifiers with generation zero, which the registry
+// never assigns. The counter may wrap; placeholders only need to be unique
+// within one ApplyCommands call.
+func nextPlaceholder() EntityID {
+	for {
+		if index := placeholderSeq.Add(1); index != 0 {
+			return EntityID{index: index}
+		}
+	}
+}
+
+type spawnCommand struct {
+	placeholder EntityID
+	bundle      Bundle
+}
+
+func (c spawnCommand) Apply(world *World) error {
+	stores := make([]ComponentStore, len(c.bundle))
+	seen := make(map[ComponentType]bool, len(c.bundle))
+	for i, entry := range c.bundle {
+		if seen[entry.Type] {
+			return fmt.Errorf("ecs: spawn bundle repeats component %s", entry.Type)
+		}
+		seen[entry.Type] = true
+		store, err := world.componentStore(entry.Type)
+		if err != nil {
+			return fmt.Errorf("ecs: spawn component %s: %w", entry.Type, err)
+		}
+		if err := world.types.check(entry.Type, entry.Value); err != nil {
+			return err
+		}
+		stores[i] = store
+	}
+
+	id, recycled := world.registry.create()
+	for i, entry := range c.bundle {
+		if err := stores[i].Set(id, entry.Value); err != nil {
+			for _, store := range stores[:i] {
+				store.Remove(id)
+			}
+			world.registry.rollbackCreate(id, recycled)
+			return fmt.Errorf("ecs: spawn component %s: %w", entry.Type, err)
+		}
+	}
+	// The spawn is journaled only once it succeeded; the cleanup above
+	// already undoes a partial one.
+	world.record(func() { world.registry.rollbackCreate(id, recycled) })
+	for i, entry := range c.bundle {
+		if world.journaling() {
+			restoreTicks := world.changes.save(entry.Type, id)
+			world.record(func() {
+				stores[i].Remove(id)
+				restoreTicks()
+			})
+		}
+		world.changes.stamp(entry.Type, id)
+	}
+	world.bindPlaceholder(c.placeholder, id)
+	for _, entry := range c.bundle {
+		if hooks := world.hooks.lookup(entry.Type); len(hooks) > 0 {
+			world.fireComponentHooks(hooks, ComponentEvent{Entity: id, Component: entry.Type, New: entry.Value}, pickOnAdd)
+		}
+	}
+	return nil
+}
+
+var _ Command = spawnCommand{}
+
+func (w *World) bindPlaceholder(placeholder, id EntityID) {
+	if w.placeholders == nil {
+		w.placeholders = make(map[EntityID]EntityID)
+	}
+	w.placeholders[placeholder] = id
+}
+
+// resolve maps a placeholder to the entity spawned for it. Other identifiers
+// are returned unchanged.
+func (w *World) resolve(id EntityID) (EntityID, error) {
+	if !id.IsPlaceholder() {
+		return id, nil
+	}
+	if real, ok := w.placeholders[id]; ok {
+		return real, nil
+	}
+	return EntityID{}, fmt.Errorf("ecs: placeholder %v was not spawned in this batch", id)
+}
@@ -0,0 +1,99 @@
+package ecs_test
+
+import (
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+type spawnPosition struct{ X, Y float64 }
+
+func newSpawnWorld(t *testing.T) (*ecs.World, ecs.Component[spawnPosition], ecs.Component[int]) {
+	t.Helper()
+	world := ecs.NewWorld()
+	position, err := ecs.RegisterComponent[spawnPosition](world, "Position", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register position: %v", err)
+	}
+	health, err := ecs.RegisterComponent[int](world, "Health", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register health: %v", err)
+	}
+	return world, position, health
+}
+
+func TestSpawnCommandResolvesPlaceholders(t *testing.T) {
+	world, position, health := newSpawnWorld(t)
+	var complete bool
+	world.AddComponentHooks(position.Type(), ecs.ComponentHooks{OnAdd: func(ctx *ecs.HookContext, event ecs.ComponentEvent) {
+		if event.New.(spawnPosition).X == 1 {
+			_, complete = health.Get(ctx.World(), event.Entity)
+		}
+	}})
+
+	turret, turretID := ecs.NewSpawnCommand(ecs.Bundle{position.With(spawnPosition{X: 1}), health.With(100)})
+	shell, shellID := ecs.NewSpawnCommand(ecs.Bundle{position.With(spawnPosition{X: 2})})
+	if !turretID.IsPlaceholder() || turretID == shellID {
+		t.Fatalf("expected distinct placeholders, got %v and %v", turretID, shellID)
+	}
+	if err := world.ApplyCommands([]ecs.Command{
+		turret,
+		shell,
+		health.AddCommand(shellID, 1),
+		ecs.NewSetParentCommand(shellID, turretID),
+	}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if world.Registry().Count() != 2 {
+		t.Fatalf("expected two spawned entities, got %d", world.Registry().Count())
+	}
+	if !complete {
+		t.Fatalf("expected OnAdd hooks to see the whole bundle")
+	}
+	var spawnedTurret, spawnedShell ecs.EntityID
+	position.Iterate(world, func(id ecs.EntityID, pos spawnPosition) bool {
+		if pos.X == 1 {
+			spawnedTurret = id
+		} else {
+			spawnedShell = id
+		}
+		return true
+	})
+	if hp, ok := health.Get(world, spawnedShell); !ok || hp != 1 {
+		t.Fatalf("expected follow-up command to target the spawned shell, got %v (%v)", hp, ok)
+	}
+	if parent, ok := world.Parent(spawnedShell); !ok || parent != spawnedTurret {
+		t.Fatalf("expected shell parented to turret, got %v (%v)", parent, ok)
+	}
+
+	if err := ecs.NewDestroyEntityCommand(shellID).Apply(world); err == nil {
+		t.Fatalf("expected placeholder from an earlier batch to be rejected")
+	}
+}
+
+func TestSpawnCommandIsAtomic(t *testing.T) {
+	world, position, health := newSpawnWorld(t)
+	failures := []ecs.Bundle{
+		{position.With(spawnPosition{}), {Type: "Missing", Value: 1}},
+		{position.With(spawnPosition{}), {Type: health.Type(), Value: "full"}},
+		{health.With(1), health.With(2)},
+	}
+	for _, bundle := range failures {
+		spawn, _ := ecs.NewSpawnCommand(bundle)
+		if err := world.ApplyCommands([]ecs.Command{spawn}); err == nil {
+			t.Fatalf("expected bundle %v to be rejected", bundle)
+		}
+	}
+	if world.Registry().Count() != 0 {
+		t.Fatalf("expected failed spawns to leave no entity, got %d", world.Registry().Count())
+	}
+	view, err := world.ViewComponent(position.Type())
+	if err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if view.Len() != 0 {
+		t.Fatalf("expected failed spawns to leave no components")
+	}
+}
@@ -1,6 +1,9 @@
 package ecs
 
-import "sync"
+import (
+	"sort"
+	"sync"
+)
 
 type storageProvider struct {
 	mu     sync.RWMutex
@@ -44,7 +47,25 @@ func (p *storageProvider) View(t ComponentType) (ComponentView, error) {
 	return store, nil
 }
 
+func (p *storageProvider) Components() []ComponentType {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make([]ComponentType, 0, len(p.stores))
+	for t := range p.stores {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
 func (p *storageProvider) Apply(world *World, commands []Command) error {
+	return ApplyInOrder(world, commands)
+}
+
+// ApplyInOrder applies commands one after another, skipping nil entries and
+// stopping at the first error. It is the default StorageProvider.Apply, for
+// custom providers that need no special batching.
+func ApplyInOrder(world *World, commands []Command) error {
 	for _, cmd := range commands {
 		if cmd == nil {
 			continue
@@ -56,4 +77,7 @@ func (p *storageProvider) Apply(world *World, commands []Command) error {
 	return nil
 }
 
-var _ StorageProvider = (*storageProvider)(nil)
+var (
+	_ StorageProvider = (*storageProvider)(nil)
+	_ ComponentLister = (*storageProvider)(nil)
+)
@@ -0,0 +1,46 @@
+package ecs
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// TraceAnnotator is an optional Tracer extension for attaching key/value
+// annotations to the span active in ctx, such as async wait times.
+type TraceAnnotator interface {
+	Annotate(ctx context.Context, key, value string)
+}
+
+// RuntimeTracer returns a Tracer backed by runtime/trace. The outermost span
+// in a context becomes a trace task and nested spans become regions, so a
+// scheduler tick shows up as one task with a region per work group and system.
+// Spans are free when no trace is being collected.
+func RuntimeTracer() Tracer {
+	return runtimeTracer{}
+}
+
+type runtimeTracer struct{}
+
+type runtimeTaskKey struct{}
+
+func (runtimeTracer) Start(ctx context.Context, name string) (context.Context, TraceSpan) {
+	if !trace.IsEnabled() {
+		return ctx, noopSpan{}
+	}
+	if ctx.Value(runtimeTaskKey{}) == nil {
+		taskCtx, task := trace.NewTask(ctx, name)
+		return context.WithValue(taskCtx, runtimeTaskKey{}, true), task
+	}
+	return ctx, trace.StartRegion(ctx, name)
+}
+
+func (runtimeTracer) Annotate(ctx context.Context, key, value string) {
+	trace.Log(ctx, key, value)
+}
+
+// annotateTrace forwards to tracer when it supports annotations.
+func annotateTrace(ctx context.Context, tracer Tracer, key, value string) {
+	if annotator, ok := tracer.(TraceAnnotator); ok {
+		annotator.Annotate(ctx, key, value)
+	}
+}
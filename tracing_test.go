@@ -0,0 +1,116 @@
+package ecs_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DangerosoDavo/ecs"
+)
+
+type spanPathKey struct{}
+
+// pathTracer records every span as its slash-separated ancestry.
+type pathTracer struct {
+	mu    sync.Mutex
+	spans []string
+	ended int
+}
+
+func (t *pathTracer) Start(ctx context.Context, name string) (context.Context, ecs.TraceSpan) {
+	path := name
+	if parent, ok := ctx.Value(spanPathKey{}).(string); ok {
+		path = parent + "/" + name
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, path)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanPathKey{}, path), pathSpan{t}
+}
+
+type pathSpan struct{ t *pathTracer }
+
+func (s pathSpan) End() {
+	s.t.mu.Lock()
+	s.t.ended++
+	s.t.mu.Unlock()
+}
+
+type subSpanSystem struct{}
+
+func (subSpanSystem) Descriptor() ecs.SystemDescriptor {
+	return ecs.SystemDescriptor{Name: "pathfind", AsyncAllowed: true}
+}
+
+func (subSpanSystem) Run(ctx context.Context, exec ecs.ExecutionContext) ecs.SystemResult {
+	_, span := exec.Tracer().Start(ctx, "astar")
+	span.End()
+	return ecs.SystemResult{}
+}
+
+func TestSchedulerOpensSpansPerTickGroupAndSystem(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	tracer := &pathTracer{}
+	scheduler.Builder().WithAsyncWorkers(1).WithInstrumentation(ecs.InstrumentationConfig{Tracer: tracer})
+
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "ai", Systems: []ecs.System{subSpanSystem{}}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "stats", Mode: ecs.WorkGroupModeAsync, Systems: []ecs.System{&testSystem{name: "report", desc: ecs.SystemDescriptor{AsyncAllowed: true}}}}); err != nil {
+		t.Fatalf("register async: %v", err)
+	}
+	if err := scheduler.Tick(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	want := map[string]bool{
+		"ecs.tick":                                    true,
+		"ecs.tick/workgroup:ai":                       true,
+		"ecs.tick/workgroup:ai/system:pathfind":       true,
+		"ecs.tick/workgroup:ai/system:pathfind/astar": true,
+		"ecs.tick/workgroup:stats":                    true,
+		"ecs.tick/workgroup:stats/system:report":      true,
+		"ecs.tick/async_wait:stats":                   true,
+	}
+	got := make(map[string]bool, len(tracer.spans))
+	for _, span := range tracer.spans {
+		got[span] = true
+	}
+	if !reflect.DeepEqual(got, want) || len(tracer.spans) != len(want) {
+		t.Fatalf("unexpected spans %v", tracer.spans)
+	}
+	if tracer.ended != len(tracer.spans) {
+		t.Fatalf("expected every span to end, started %d ended %d", len(tracer.spans), tracer.ended)
+	}
+}
+
+func TestRuntimeTracerRecordsExecutionTrace(t *testing.T) {
+	scheduler, err := ecs.NewScheduler(ecs.NewWorld())
+	if err != nil {
+		t.Fatalf("new scheduler: %v", err)
+	}
+	scheduler.Builder().WithInstrumentation(ecs.InstrumentationConfig{EnableTrace: true})
+	if _, err := scheduler.RegisterWorkGroup(ecs.WorkGroupConfig{ID: "ai", Systems: []ecs.System{subSpanSystem{}}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = scheduler.RunWithTrace(context.Background(), &out, func() error {
+		return scheduler.Run(context.Background(), 2, time.Millisecond)
+	})
+	if err != nil {
+		t.Fatalf("run with trace: %v", err)
+	}
+	if !strings.Contains(out.String(), "workgroup:ai") || !strings.Contains(out.String(), "system:pathfind") {
+		t.Fatalf("expected trace to name the work group and system regions")
+	}
+}
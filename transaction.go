@@ -0,0 +1,230 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ApplyMode selects how ApplyCommands handles a failing command.
+type ApplyMode uint8
+
+const (
+	// ApplyFailFast stops at the first failing command and keeps the effects
+	// of the commands before it.
+	ApplyFailFast ApplyMode = iota
+	// ApplyTransactional stops at the first failing command and rolls back
+	// every change the batch made to the registry, component stores, change
+	// ticks and hierarchy. Side effects of hooks and of custom commands that
+	// mutate state other than through the world's built-in commands are not
+	// undone.
+	ApplyTransactional
+	// ApplyBestEffort applies every command it can and reports the failures
+	// as an *ApplyError.
+	ApplyBestEffort
+)
+
+// WithApplyMode sets the mode used by ApplyCommands. The default is
+// ApplyFailFast.
+func WithApplyMode(mode ApplyMode) WorldOption {
+	return func(w *World) {
+		w.applyMode = mode
+	}
+}
+
+// CommandError reports a command that failed during ApplyCommands. Index is
+// the command's position in the batch, or -1 for a follow-up queued by a hook.
+type CommandError struct {
+	Index   int
+	Command Command
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("hook follow-up %T: %v", unwrapCommand(e.Command), e.Err)
+	}
+	return fmt.Sprintf("command %d (%T): %v", e.Index, unwrapCommand(e.Command), e.Err)
+}
+
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// ApplyError aggregates the failures of an ApplyBestEffort batch.
+type ApplyError struct {
+	Failures []*CommandError
+}
+
+func (e *ApplyError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		parts[i] = failure.Error()
+	}
+	return fmt.Sprintf("ecs: %d command(s) failed: %s", len(e.Failures), strings.Join(parts, "; "))
+}
+
+func (e *ApplyError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
+}
+
+// ApplyCommandsWithMode is ApplyCommands with an explicit ApplyMode.
+func (w *World) ApplyCommandsWithMode(commands []Command, mode ApplyMode) error {
+	w.applyMu.Lock()
+	defer w.applyMu.Unlock()
+	defer func() { clear(w.placeholders) }()
+	switch mode {
+	case ApplyTransactional:
+		return w.applyTransactional(commands)
+	case ApplyBestEffort:
+		return w.applyBestEffort(commands)
+	default:
+		if err := w.storage.Apply(w, commands); err != nil {
+			w.hooks.drain()
+			return err
+		}
+		return w.applyHookFollowUps(func(pending []Command) error {
+			return w.storage.Apply(w, pending)
+		})
+	}
+}
+
+func (w *World) applyTransactional(commands []Command) error {
+	journal := &applyJournal{}
+	w.journal = journal
+	defer func() { w.journal = nil }()
+	fail := func(err error) error {
+		w.hooks.drain()
+		journal.rollback()
+		journal.settle(ErrCommandsRolledBack)
+		return fmt.Errorf("%w: %w", ErrCommandsRolledBack, err)
+	}
+	for i, cmd := range commands {
+		if cmd == nil {
+			continue
+		}
+		if err := w.storage.Apply(w, []Command{cmd}); err != nil {
+			return fail(&CommandError{Index: i, Command: cmd, Err: err})
+		}
+	}
+	if err := w.applyHookFollowUps(func(pending []Command) error {
+		for _, cmd := range pending {
+			if cmd == nil {
+				continue
+			}
+			if err := w.storage.Apply(w, []Command{cmd}); err != nil {
+				return &CommandError{Index: -1, Command: cmd, Err: err}
+			}
+		}
+		return nil
+	}); err != nil {
+		return fail(err)
+	}
+	journal.settle(nil)
+	return nil
+}
+
+func (w *World) applyBestEffort(commands []Command) error {
+	var failures []*CommandError
+	apply := func(index int, cmd Command) {
+		if cmd == nil {
+			return
+		}
+		if err := w.storage.Apply(w, []Command{cmd}); err != nil {
+			failures = append(failures, &CommandError{Index: index, Command: cmd, Err: err})
+		}
+	}
+	for i, cmd := range commands {
+		apply(i, cmd)
+	}
+	err := w.applyHookFollowUps(func(pending []Command) error {
+		for _, cmd := range pending {
+			apply(-1, cmd)
+		}
+		return nil
+	})
+	if len(failures) == 0 {
+		return err
+	}
+	applyErr := &ApplyError{Failures: failures}
+	if err != nil {
+		return errors.Join(applyErr, err)
+	}
+	return applyErr
+}
+
+// applyJournal records how to undo each mutation of a transactional batch.
+// Undo steps run in reverse, so each one sees the state its mutation left.
+type applyJournal struct {
+	undo     []func()
+	observed []func(rollback error) // command observer reports held until the batch settles
+}
+
+func (j *applyJournal) observe(report func(rollback error)) {
+	j.observed = append(j.observed, report)
+}
+
+// settle delivers held observer reports; rollback is nil when the batch
+// committed.
+func (j *applyJournal) settle(rollback error) {
+	for _, report := range j.observed {
+		report(rollback)
+	}
+	j.observed = nil
+}
+
+func (j *applyJournal) add(undo func()) {
+	j.undo = append(j.undo, undo)
+}
+
+func (j *applyJournal) rollback() {
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+	j.undo = nil
+}
+
+// journaling reports whether mutations must be recorded.
+func (w *World) journaling() bool {
+	return w.journal != nil
+}
+
+func (w *World) record(undo func()) {
+	if w.journal != nil {
+		w.journal.add(undo)
+	}
+}
+
+// createEntity allocates an entity, journaling the allocation.
+func (w *World) createEntity() EntityID {
+	id, recycled := w.registry.create()
+	w.record(func() { w.registry.rollbackCreate(id, recycled) })
+	return id
+}
+
+// journalComponent records the component value and change ticks held by id
+// before a mutation of t.
+func (w *World) journalComponent(store ComponentStore, t ComponentType, id EntityID) {
+	if !w.journaling() {
+		return
+	}
+	old, had := store.Get(id)
+	restoreTicks := w.changes.save(t, id)
+	w.record(func() {
+		if had {
+			_ = store.Set(id, old)
+		} else {
+			store.Remove(id)
+		}
+		restoreTicks()
+	})
+}
+
+// journalHierarchy records the links of ids before a hierarchy mutation.
+func (w *World) journalHierarchy(ids ...EntityID) {
+	if w.journaling() {
+		w.record(w.hierarchy.save(ids...))
+	}
+}
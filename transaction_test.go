@@ -0,0 +1,147 @@
+package ecs_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/DangerosoDavo/ecs"
+	ecsstorage "github.com/DangerosoDavo/ecs/ecs/storage"
+)
+
+func newTransactionWorld(t *testing.T, mode ecs.ApplyMode) (*ecs.World, ecs.Component[snapshotPosition]) {
+	t.Helper()
+	world := ecs.NewWorld(ecs.WithApplyMode(mode))
+	position, err := ecs.RegisterComponent[snapshotPosition](world, "Position", ecsstorage.NewDenseStrategy())
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := world.RegisterComponentCodec("Position", ecs.GobCodec[snapshotPosition]()); err != nil {
+		t.Fatalf("register codec: %v", err)
+	}
+	return world, position
+}
+
+func snapshotBytes(t *testing.T, world *ecs.World) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := world.Snapshot(&buf); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestTransactionalApplyRollsBack(t *testing.T) {
+	world, position := newTransactionWorld(t, ecs.ApplyTransactional)
+	parent := world.Registry().Create()
+	child := world.Registry().Create()
+	recycled := world.Registry().Create()
+	if err := world.ApplyCommands([]ecs.Command{
+		position.AddCommand(parent, snapshotPosition{X: 1}),
+		position.AddCommand(child, snapshotPosition{X: 2}),
+		ecs.NewSetParentCommand(child, parent),
+		ecs.NewDestroyEntityCommand(recycled),
+	}); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	before := snapshotBytes(t, world)
+	ticks, _ := world.ComponentTicks("Position", parent)
+
+	var created ecs.EntityID
+	spawn, spawned := ecs.NewSpawnCommand(ecs.Bundle{position.With(snapshotPosition{X: 3})})
+	err := world.ApplyCommands([]ecs.Command{
+		ecs.NewCreateEntityCommand(&created),
+		spawn,
+		ecs.NewSetParentCommand(spawned, parent),
+		position.AddCommand(parent, snapshotPosition{X: 9}),
+		ecs.NewRemoveComponentCommand(child, "Position"),
+		ecs.NewDestroyRecursiveCommand(parent),
+		ecs.NewDestroyEntityCommand(recycled),
+	})
+	if !errors.Is(err, ecs.ErrCommandsRolledBack) {
+		t.Fatalf("expected ErrCommandsRolledBack, got %v", err)
+	}
+	var cmdErr *ecs.CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Index != 6 {
+		t.Fatalf("expected failure at index 6, got %v", err)
+	}
+
+	if after := snapshotBytes(t, world); !bytes.Equal(before, after) {
+		t.Fatalf("expected registry, components and hierarchy restored")
+	}
+	if got, _ := world.ComponentTicks("Position", parent); got != ticks {
+		t.Fatalf("expected change ticks restored, got %+v want %+v", got, ticks)
+	}
+	if !created.IsZero() {
+		t.Fatalf("expected create target reset, got %v", created)
+	}
+	if children := world.Children(parent); len(children) != 1 || children[0] != child {
+		t.Fatalf("expected original children, got %v", children)
+	}
+}
+
+func TestTransactionalApplyRollsBackHookFollowUps(t *testing.T) {
+	world, position := newTransactionWorld(t, ecs.ApplyTransactional)
+	world.AddComponentHooks(position.Type(), ecs.ComponentHooks{OnAdd: func(ctx *ecs.HookContext, event ecs.ComponentEvent) {
+		ctx.Defer(ecs.NewAddComponentCommand(event.Entity, "Missing", 1))
+	}})
+	spawn, _ := ecs.NewSpawnCommand(ecs.Bundle{position.With(snapshotPosition{})})
+	err := world.ApplyCommands([]ecs.Command{spawn})
+	var cmdErr *ecs.CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Index != -1 || !errors.Is(err, ecs.ErrComponentNotRegistered) {
+		t.Fatalf("expected a failed hook follow-up, got %v", err)
+	}
+	if world.Registry().Count() != 0 {
+		t.Fatalf("expected spawn rolled back")
+	}
+}
+
+func TestBestEffortApplyReportsEveryFailure(t *testing.T) {
+	world, position := newTransactionWorld(t, ecs.ApplyFailFast)
+	a := world.Registry().Create()
+	b := world.Registry().Create()
+	err := world.ApplyCommandsWithMode([]ecs.Command{
+		position.AddCommand(a, snapshotPosition{X: 1}),
+		ecs.NewAddComponentCommand(a, "Missing", 1),
+		position.AddCommand(b, snapshotPosition{X: 2}),
+		ecs.NewAddComponentCommand(b, "Position", "wrong type"),
+	}, ecs.ApplyBestEffort)
+
+	var applyErr *ecs.ApplyError
+	if !errors.As(err, &applyErr) || len(applyErr.Failures) != 2 {
+		t.Fatalf("expected two failures, got %v", err)
+	}
+	if applyErr.Failures[0].Index != 1 || applyErr.Failures[1].Index != 3 {
+		t.Fatalf("unexpected failure indices: %v", err)
+	}
+	if !errors.Is(err, ecs.ErrComponentNotRegistered) || !errors.Is(err, ecs.ErrComponentTypeMismatch) {
+		t.Fatalf("expected underlying errors to be preserved, got %v", err)
+	}
+	if !position.Has(world, a) || !position.Has(world, b) {
+		t.Fatalf("expected successful commands to apply")
+	}
+}
+
+func TestTransactionalRollbackOfWrappedGeneration(t *testing.T) {
+	src := ecs.NewWorld()
+	src.Registry().Destroy(src.Registry().Create())
+	data := snapshotBytes(t, src)
+	// Rewrite the freed slot's generation (offset 18, after an empty manifest)
+	// to 0, as if it had wrapped around.
+	copy(data[18:22], []byte{0, 0, 0, 0})
+
+	world := ecs.NewWorld(ecs.WithApplyMode(ecs.ApplyTransactional))
+	if err := world.Restore(bytes.NewReader(data)); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	before := snapshotBytes(t, world)
+	// The create recycles slot 0 at generation 1, like a fresh slot would.
+	stale := ecs.EntityIDFromParts(7, 1)
+	err := world.ApplyCommands([]ecs.Command{ecs.NewCreateEntityCommand(nil), ecs.NewDestroyEntityCommand(stale)})
+	if !errors.Is(err, ecs.ErrCommandsRolledBack) {
+		t.Fatalf("expected rollback, got %v", err)
+	}
+	if !bytes.Equal(before, snapshotBytes(t, world)) {
+		t.Fatalf("rollback did not return the recycled slot to the free list")
+	}
+}
@@ -2,6 +2,7 @@ package ecs
 
 import (
 	"context"
+	"runtime/debug"
 	"sync"
 )
 
@@ -59,18 +60,31 @@ func (p *workerPool) worker() {
 			if !ok {
 				return
 			}
-			p.execute(job)
+			executeJob(job)
 		case <-p.closed:
 			return
 		}
 	}
 }
 
-func (p *workerPool) execute(job jobRequest) {
+// spawnJob runs fn on a goroutine of its own, for jobs that would otherwise
+// hold a pool worker across ticks.
+func spawnJob(ctx context.Context, fn func(context.Context) jobResult) *jobHandle {
+	result := make(chan jobResult, 1)
+	go executeJob(jobRequest{ctx: ctx, fn: fn, result: result})
+	return &jobHandle{result: result}
+}
+
+func executeJob(job jobRequest) {
 	if job.result == nil {
 		return
 	}
 	defer close(job.result)
+	defer func() {
+		if r := recover(); r != nil {
+			job.result <- jobResult{err: &SystemPanicError{Value: r, Stack: debug.Stack()}}
+		}
+	}()
 	if job.fn == nil {
 		job.result <- jobResult{}
 		return
@@ -143,6 +157,33 @@ func (h *jobHandle) Wait() jobResult {
 	return res
 }
 
+// Poll returns the result without blocking once the job has finished. The
+// result is handed out only once.
+func (h *jobHandle) Poll() (jobResult, bool) {
+	if h == nil || h.result == nil {
+		return jobResult{}, true
+	}
+	select {
+	case res := <-h.result:
+		return res, true
+	default:
+		return jobResult{}, false
+	}
+}
+
+// WaitContext is Wait bounded by ctx.
+func (h *jobHandle) WaitContext(ctx context.Context) (jobResult, error) {
+	if h == nil || h.result == nil {
+		return jobResult{}, nil
+	}
+	select {
+	case res := <-h.result:
+		return res, nil
+	case <-ctx.Done():
+		return jobResult{}, ctx.Err()
+	}
+}
+
 func safeSendJob(ch chan jobRequest, job jobRequest) (ok bool) {
 	defer func() {
 		if recover() != nil {
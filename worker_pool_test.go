@@ -2,6 +2,7 @@ package ecs
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -63,3 +64,20 @@ func TestWorkerPoolNilExecutesInline(t *testing.T) {
 		t.Fatalf("expected inline job to run")
 	}
 }
+
+func TestWorkerPoolRecoversPanickingJobs(t *testing.T) {
+	pool := newWorkerPool(1)
+	defer pool.Close()
+
+	handle := pool.Submit(context.Background(), func(context.Context) jobResult { panic("boom") })
+	res := handle.Wait()
+	var panicErr *SystemPanicError
+	if !errors.As(res.err, &panicErr) || panicErr.Value != "boom" {
+		t.Fatalf("expected SystemPanicError, got %v", res.err)
+	}
+
+	// The worker must survive to run later jobs.
+	if res := pool.Submit(context.Background(), func(context.Context) jobResult { return jobResult{} }).Wait(); res.err != nil {
+		t.Fatalf("expected pool to keep working, got %v", res.err)
+	}
+}
@@ -1,5 +1,11 @@
 package ecs
 
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
 type WorldOption func(*World)
 
 // NewWorld constructs a world with default registries and providers.
@@ -59,15 +65,190 @@ func (w *World) Resources() ResourceContainer {
 
 // RegisterComponent allows callers to register component storage strategies.
 func (w *World) RegisterComponent(t ComponentType, strategy StorageStrategy) error {
-    return w.storage.RegisterComponent(t, strategy)
+	if err := w.storage.RegisterComponent(t, strategy); err != nil {
+		return err
+	}
+	w.registered.add(t)
+	return nil
 }
 
 // ViewComponent retrieves a component view by type.
 func (w *World) ViewComponent(t ComponentType) (ComponentView, error) {
-    return w.storage.View(t)
+	return w.storage.View(t)
+}
+
+// registeredComponents remembers the component types registered through the
+// world, for providers that do not implement ComponentLister.
+type registeredComponents struct {
+	mu    sync.Mutex
+	types []ComponentType
 }
 
-// ApplyCommands executes deferred commands against the world.
+func (r *registeredComponents) add(t ComponentType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types = append(r.types, t)
+}
+
+func (r *registeredComponents) list() []ComponentType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := append([]ComponentType(nil), r.types...)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// componentTypes lists the registered component types in name order.
+func (w *World) componentTypes() []ComponentType {
+	if lister, ok := w.storage.(ComponentLister); ok {
+		return lister.Components()
+	}
+	return w.registered.list()
+}
+
+// componentStore resolves the writable store backing a component type.
+func (w *World) componentStore(t ComponentType) (ComponentStore, error) {
+	view, err := w.storage.View(t)
+	if err != nil {
+		return nil, err
+	}
+	store, ok := view.(ComponentStore)
+	if !ok {
+		return nil, fmt.Errorf("ecs: component %s is not writable", t)
+	}
+	return store, nil
+}
+
+// setComponent stores value on the entity and fires OnAdd or OnChange hooks.
+func (w *World) setComponent(id EntityID, t ComponentType, value any) error {
+	store, err := w.componentStore(t)
+	if err != nil {
+		return err
+	}
+	if err := w.types.check(t, value); err != nil {
+		return err
+	}
+	w.journalComponent(store, t, id)
+	hooks := w.hooks.lookup(t)
+	if len(hooks) == 0 {
+		if err := store.Set(id, value); err != nil {
+			return err
+		}
+		w.changes.stamp(t, id)
+		return nil
+	}
+	old, replaced := store.Get(id)
+	if err := store.Set(id, value); err != nil {
+		return err
+	}
+	w.changes.stamp(t, id)
+	event := ComponentEvent{Entity: id, Component: t, Old: old, New: value}
+	if replaced {
+		w.fireComponentHooks(hooks, event, pickOnChange)
+	} else {
+		w.fireComponentHooks(hooks, event, pickOnAdd)
+	}
+	return nil
+}
+
+// removeComponent deletes the entity's component and fires OnRemove hooks when
+// a value was present.
+func (w *World) removeComponent(id EntityID, t ComponentType) error {
+	store, err := w.componentStore(t)
+	if err != nil {
+		return err
+	}
+	w.journalComponent(store, t, id)
+	w.changes.forget(t, id)
+	hooks := w.hooks.lookup(t)
+	if len(hooks) == 0 {
+		store.Remove(id)
+		return nil
+	}
+	old, had := store.Get(id)
+	if store.Remove(id) && had {
+		w.fireComponentHooks(hooks, ComponentEvent{Entity: id, Component: t, Old: old}, pickOnRemove)
+	}
+	return nil
+}
+
+// destroyEntity removes every component held by the entity before releasing
+// its identifier, so stores never report data for dead entities. OnRemove
+// hooks fire once the entity is no longer alive.
+func (w *World) destroyEntity(id EntityID) error {
+	if !w.registry.IsAlive(id) {
+		return fmt.Errorf("ecs: destroy stale entity %v", id)
+	}
+	var removed []ComponentEvent
+	var types []ComponentType
+	for _, t := range w.componentTypes() {
+		if view, err := w.storage.View(t); err == nil && view.Has(id) {
+			types = append(types, t)
+		}
+	}
+	if w.journaling() {
+		for _, t := range types {
+			if store, err := w.componentStore(t); err == nil {
+				w.journalComponent(store, t, id)
+			}
+		}
+	}
+	for _, t := range types {
+		if len(w.hooks.lookup(t)) == 0 {
+			continue
+		}
+		view, err := w.storage.View(t)
+		if err != nil {
+			continue
+		}
+		if old, ok := view.Get(id); ok {
+			removed = append(removed, ComponentEvent{Entity: id, Component: t, Old: old})
+		}
+	}
+	remover, bulk := w.storage.(EntityRemover)
+	if bulk {
+		remover.RemoveEntity(id)
+	}
+	for _, t := range types {
+		store, err := w.componentStore(t)
+		if err != nil {
+			continue
+		}
+		if !bulk {
+			store.Remove(id)
+		}
+		w.changes.forget(t, id)
+		if releaser, ok := store.(EntityReleaser); ok {
+			releaser.ReleaseEntity(id)
+		}
+	}
+	if !w.registry.Destroy(id) {
+		return fmt.Errorf("ecs: destroy stale entity %v", id)
+	}
+	w.record(func() { w.registry.rollbackDestroy(id) })
+	if w.journaling() {
+		parent, _ := w.hierarchy.parent(id)
+		w.journalHierarchy(append(w.hierarchy.childrenOf(id), id, parent)...)
+	}
+	w.hierarchy.forget(id)
+	for _, event := range removed {
+		w.fireComponentHooks(w.hooks.lookup(event.Component), event, pickOnRemove)
+	}
+	return nil
+}
+
+// ApplyCommands executes deferred commands against the world using the mode
+// set by WithApplyMode. Follow-up commands queued by component hooks apply
+// before it returns.
 func (w *World) ApplyCommands(commands []Command) error {
-    return w.storage.Apply(w, commands)
+	return w.ApplyCommandsWithMode(commands, w.applyMode)
+}
+
+// Read runs fn while no commands are being applied, for goroutines outside the
+// scheduler that read the world while ticks run. Systems never need it. Read
+// must not be called from hooks or commands.
+func (w *World) Read(fn func(*World) error) error {
+	w.applyMu.RLock()
+	defer w.applyMu.RUnlock()
+	return fn(w)
 }